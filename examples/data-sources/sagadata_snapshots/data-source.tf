@@ -0,0 +1,22 @@
+data "sagadata_snapshots" "nightly" {
+  filter = {
+    source_instance_id = "18efeec8-94f0-4776-8ff2-5e9b49c74608"
+    name_prefix        = "nightly-"
+  }
+}
+
+data "sagadata_snapshots" "expired" {
+  filter = {
+    name_prefix = "nightly-"
+    older_than  = "168h"
+  }
+}
+
+locals {
+  nightly_snapshots = data.sagadata_snapshots.nightly.snapshots
+
+  # Keep the seven newest snapshots and prune the rest
+  prunable_snapshot_ids = [
+    for snapshot in slice(local.nightly_snapshots, min(7, length(local.nightly_snapshots)), length(local.nightly_snapshots)) : snapshot.id
+  ]
+}
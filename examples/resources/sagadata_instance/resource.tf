@@ -50,3 +50,66 @@ resource "sagadata_instance" "with_k8s_cluster" {
     "my-ssh-key-id"
   ]
 }
+
+# Example with capacity fallback
+resource "sagadata_instance" "gpu" {
+  name   = "gpu-worker"
+  region = "NORD-NO-KRS-1"
+
+  image = "ubuntu-24.04"
+  type  = "vcpu-8_memory-48g_nvidia-h100-1"
+
+  capacity_fallback = {
+    placement_options = ["B"]
+    types             = ["vcpu-16_memory-96g_nvidia-h100-2"]
+    retry             = true
+  }
+
+  ssh_key_ids = [
+    "my-ssh-key-id"
+  ]
+
+  timeouts = {
+    create = "2h"
+  }
+}
+
+# Example waiting for SSH
+resource "sagadata_instance" "ssh_ready" {
+  name   = "ssh-ready"
+  region = "NORD-NO-KRS-1"
+
+  image = "ubuntu-24.04"
+  type  = "vcpu-2_memory-4g"
+
+  ssh_key_ids = [
+    "my-ssh-key-id"
+  ]
+
+  wait_for = {
+    tcp_port = 22
+    timeout  = "5m"
+  }
+}
+
+# Example parking a pool of instances overnight
+variable "gpu_pool_status" {
+  type    = string
+  default = "active"
+}
+
+resource "sagadata_instance" "gpu_pool" {
+  count = 4
+
+  name   = "gpu-pool-${count.index}"
+  region = "NORD-NO-KRS-1"
+
+  image = "ubuntu-24.04"
+  type  = "vcpu-8_memory-48g_nvidia-h100-1"
+
+  ssh_key_ids = [
+    "my-ssh-key-id"
+  ]
+
+  desired_status = var.gpu_pool_status
+}
@@ -0,0 +1,11 @@
+terraform {
+  required_providers {
+    sagadata = {
+      source = "sagadata-public/sagadata"
+    }
+  }
+}
+
+provider "sagadata" {
+  # optional configuration...
+}
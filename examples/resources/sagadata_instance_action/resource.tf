@@ -0,0 +1,21 @@
+resource "sagadata_instance" "example" {
+  name   = "example"
+  region = "NORD-NO-KRS-1"
+
+  image = "ubuntu-24.04"
+  type  = "vcpu-8_memory-48g_nvidia-h100-1"
+
+  ssh_key_ids = [
+    "my-ssh-key-id"
+  ]
+}
+
+# Reset the instance whenever the driver version changes
+resource "sagadata_instance_action" "reset" {
+  instance_id = sagadata_instance.example.id
+  action      = "reset"
+
+  triggers = {
+    driver_version = "570.124.06"
+  }
+}
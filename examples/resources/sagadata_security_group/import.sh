@@ -1 +1,4 @@
 terraform import sagadata_security_group.example 18efeec8-94f0-4776-8ff2-5e9b49c74608
+
+# by region and name
+terraform import sagadata_security_group.example NORD-NO-KRS-1/my-security-group
@@ -8,3 +8,10 @@ resource "sagadata_snapshot" "example" {
 
   retain_on_delete = true # optional
 }
+
+resource "sagadata_snapshot" "replicated" {
+  name               = "replicated"
+  source_instance_id = sagadata_instance.target.id
+
+  replica_regions = ["EUW-NL-AMS-1", "NA-CA-FTS-1"] # optional
+}
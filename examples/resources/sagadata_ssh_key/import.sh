@@ -1 +1,4 @@
 terraform import sagadata_ssh_key.example 18efeec8-94f0-4776-8ff2-5e9b49c74608
+
+# by name
+terraform import sagadata_ssh_key.example my-ssh-key
@@ -0,0 +1,80 @@
+package decreaseplanmodifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// BehaviorError rejects the plan when the value is decreased.
+	BehaviorError = "error"
+
+	// BehaviorReplace replaces the resource when the value is decreased.
+	BehaviorReplace = "replace"
+)
+
+// AllBehaviors lists the supported values of the behavior attribute.
+var AllBehaviors = []string{BehaviorError, BehaviorReplace}
+
+var _ planmodifier.Int64 = (*decreaseInt64AttributePlanModifier)(nil)
+
+// decreaseInt64AttributePlanModifier handles decreases of an attribute (types.Int64) that can only grow.
+type decreaseInt64AttributePlanModifier struct {
+	BehaviorPath path.Path
+}
+
+// Int64 is a helper to instantiate a decreaseInt64AttributePlanModifier. The behavior on decrease
+// is read from the attribute at behaviorPath and defaults to BehaviorError if not set.
+func Int64(behaviorPath path.Path) planmodifier.Int64 {
+	return &decreaseInt64AttributePlanModifier{
+		BehaviorPath: behaviorPath,
+	}
+}
+
+func (apm *decreaseInt64AttributePlanModifier) Description(ctx context.Context) string {
+	return apm.MarkdownDescription(ctx)
+}
+
+func (apm *decreaseInt64AttributePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("The value cannot be decreased in place, see `%s`.", apm.BehaviorPath)
+}
+
+func (apm *decreaseInt64AttributePlanModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, res *planmodifier.Int64Response) {
+	// Nothing to compare against on create
+	if req.State.Raw.IsNull() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+
+	// Nothing to compare against on destroy or if the value is not yet known
+	if req.Plan.Raw.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if req.PlanValue.ValueInt64() >= req.StateValue.ValueInt64() {
+		return
+	}
+
+	var behavior types.String
+
+	res.Diagnostics.Append(req.Config.GetAttribute(ctx, apm.BehaviorPath, &behavior)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if behavior.ValueString() == BehaviorReplace {
+		res.RequiresReplace = true
+		return
+	}
+
+	res.Diagnostics.AddAttributeError(
+		req.Path,
+		"Value Cannot Be Decreased",
+		fmt.Sprintf("The value of %q cannot be decreased from %d to %d in place. "+
+			"Either keep the current value or set %q to %q to replace the resource, which destroys its data.",
+			req.Path, req.StateValue.ValueInt64(), req.PlanValue.ValueInt64(), apm.BehaviorPath, BehaviorReplace),
+	)
+}
@@ -0,0 +1,150 @@
+package decreaseplanmodifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestInt64(t *testing.T) {
+	ctx := context.Background()
+
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"size":             schema.Int64Attribute{Required: true},
+			"on_size_decrease": schema.StringAttribute{Optional: true},
+		},
+	}
+	testType := testSchema.Type().TerraformType(ctx)
+
+	// newRaw returns an object of the test schema, or null without a size.
+	newRaw := func(size tftypes.Value, behavior tftypes.Value) tftypes.Value {
+		if size.IsNull() {
+			return tftypes.NewValue(testType, nil)
+		}
+
+		return tftypes.NewValue(testType, map[string]tftypes.Value{
+			"size":             size,
+			"on_size_decrease": behavior,
+		})
+	}
+
+	null := tftypes.NewValue(tftypes.Number, nil)
+	unknown := tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)
+	size := func(value int64) tftypes.Value {
+		return tftypes.NewValue(tftypes.Number, value)
+	}
+
+	testCases := map[string]struct {
+		state         tftypes.Value
+		plan          tftypes.Value
+		behavior      tftypes.Value
+		expectReplace bool
+		expectError   bool
+	}{
+		"increase": {
+			state: size(100),
+			plan:  size(200),
+		},
+		"unchanged": {
+			state: size(100),
+			plan:  size(100),
+		},
+		"decrease without behavior": {
+			state:       size(200),
+			plan:        size(100),
+			expectError: true,
+		},
+		"decrease with error": {
+			state:       size(200),
+			plan:        size(100),
+			behavior:    tftypes.NewValue(tftypes.String, BehaviorError),
+			expectError: true,
+		},
+		"decrease with replace": {
+			state:         size(200),
+			plan:          size(100),
+			behavior:      tftypes.NewValue(tftypes.String, BehaviorReplace),
+			expectReplace: true,
+		},
+		"create": {
+			state: null,
+			plan:  size(100),
+		},
+		"destroy": {
+			state: size(200),
+			plan:  null,
+		},
+		"unknown plan": {
+			state: size(200),
+			plan:  unknown,
+		},
+		"unknown state": {
+			state: unknown,
+			plan:  size(100),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			behavior := testCase.behavior
+			if behavior.Type() == nil {
+				behavior = tftypes.NewValue(tftypes.String, nil)
+			}
+
+			stateRaw := newRaw(testCase.state, behavior)
+			planRaw := newRaw(testCase.plan, behavior)
+
+			var stateValue, planValue types.Int64
+			state := tfsdk.State{Schema: testSchema, Raw: stateRaw}
+			plan := tfsdk.Plan{Schema: testSchema, Raw: planRaw}
+			config := tfsdk.Config{Schema: testSchema, Raw: planRaw}
+
+			if !stateRaw.IsNull() {
+				if diags := state.GetAttribute(ctx, path.Root("size"), &stateValue); diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+			} else {
+				stateValue = types.Int64Null()
+			}
+			if !planRaw.IsNull() {
+				if diags := plan.GetAttribute(ctx, path.Root("size"), &planValue); diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+			} else {
+				planValue = types.Int64Null()
+			}
+
+			req := planmodifier.Int64Request{
+				Path:        path.Root("size"),
+				Config:      config,
+				ConfigValue: planValue,
+				Plan:        plan,
+				PlanValue:   planValue,
+				State:       state,
+				StateValue:  stateValue,
+			}
+			resp := &planmodifier.Int64Response{PlanValue: req.PlanValue}
+
+			Int64(path.Root("on_size_decrease")).PlanModifyInt64(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			if resp.RequiresReplace != testCase.expectReplace {
+				t.Errorf("expected requires replace %t, got %t", testCase.expectReplace, resp.RequiresReplace)
+			}
+
+			if !resp.PlanValue.Equal(req.PlanValue) {
+				t.Errorf("expected the plan value %s to be kept, got %s", req.PlanValue, resp.PlanValue)
+			}
+		})
+	}
+}
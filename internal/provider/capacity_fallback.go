@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	capacityRetryInitialBackoff = 30 * time.Second
+	capacityRetryMaxBackoff     = 5 * time.Minute
+)
+
+// capacityCandidate is an instance type and placement option to create an
+// instance with.
+type capacityCandidate struct {
+	Type string
+
+	// PlacementOption Nil lets the API choose the placement option.
+	PlacementOption *string
+}
+
+// capacityFallbackCandidates returns the candidates to try in order: the
+// configured type with the configured and each fallback placement option,
+// followed by each fallback type with the same placement options.
+func capacityFallbackCandidates(instanceType string, placementOption *string, fallbackTypes []string, fallbackPlacementOptions []string) []capacityCandidate {
+	instanceTypes := []string{instanceType}
+	for _, fallbackType := range fallbackTypes {
+		if !slices.Contains(instanceTypes, fallbackType) {
+			instanceTypes = append(instanceTypes, fallbackType)
+		}
+	}
+
+	placementOptions := []*string{placementOption}
+	for _, fallbackPlacementOption := range fallbackPlacementOptions {
+		if !slices.ContainsFunc(placementOptions, func(p *string) bool { return p != nil && *p == fallbackPlacementOption }) {
+			placementOptions = append(placementOptions, pointer(fallbackPlacementOption))
+		}
+	}
+
+	candidates := make([]capacityCandidate, 0, len(instanceTypes)*len(placementOptions))
+	for _, t := range instanceTypes {
+		for _, p := range placementOptions {
+			candidates = append(candidates, capacityCandidate{Type: t, PlacementOption: p})
+		}
+	}
+
+	return candidates
+}
+
+// capacityRetryBackoff returns the time to wait before the given retry of all
+// candidates, doubling from the initial up to the maximum backoff.
+func capacityRetryBackoff(retry int) time.Duration {
+	backoff := capacityRetryInitialBackoff
+	for range retry {
+		backoff *= 2
+		if backoff >= capacityRetryMaxBackoff {
+			return capacityRetryMaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// capacityFallbackContains returns whether the known list of a capacity
+// fallback contains the value.
+func capacityFallbackContains(ctx context.Context, list types.List, value string) bool {
+	if list.IsNull() || list.IsUnknown() {
+		return false
+	}
+
+	var values []string
+	if diags := list.ElementsAs(ctx, &values, false); diags.HasError() {
+		return false
+	}
+
+	return slices.Contains(values, value)
+}
+
+// createInstanceWithCapacityFallback creates the instance with the first
+// candidate which has capacity. With retry it waits with a backoff and tries
+// all candidates again until the context is done.
+func (r *InstanceResource) createInstanceWithCapacityFallback(ctx context.Context, body sagadata.CreateInstanceJSONRequestBody, candidates []capacityCandidate, retry bool) (*sagadata.CreateInstanceResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var capacityError *ClientError
+
+	for attempt := 0; ; attempt++ {
+		for i, candidate := range candidates {
+			// Report the missing capacity rather than a failed request when
+			// the create timeout expired after a candidate without capacity
+			if ctx.Err() != nil && capacityError != nil {
+				attempts := attempt
+				if i > 0 {
+					attempts++
+				}
+
+				diags.Append(capacityTimeoutDiagnostic(capacityError, attempts))
+				return nil, diags
+			}
+
+			body.Type = sagadata.InstanceType(candidate.Type)
+			body.PlacementOption = candidate.PlacementOption
+
+			response, err := r.client.CreateInstanceWithResponse(ctx, body)
+			if err != nil && ctx.Err() != nil && capacityError != nil {
+				diags.Append(capacityTimeoutDiagnostic(capacityError, attempt+1))
+				return nil, diags
+			}
+			if err != nil {
+				diags.AddError("Client Error", generateErrorMessage("create instance", err))
+				return nil, diags
+			}
+
+			if response.JSON201 != nil {
+				return response, nil
+			}
+
+			clientError := NewClientError("create instance", instanceErrorFieldAttributes, ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			})
+			if clientError.Kind != ClientErrorInsufficientCapacity {
+				diags.Append(clientError.Diagnostic())
+				return nil, diags
+			}
+
+			tflog.Info(ctx, "no capacity for the instance, trying the next capacity fallback", map[string]any{
+				"type":             candidate.Type,
+				"placement_option": candidate.PlacementOption,
+			})
+
+			capacityError = clientError
+		}
+
+		if !retry {
+			diags.Append(capacityError.Diagnostic())
+			return nil, diags
+		}
+
+		backoff := capacityRetryBackoff(attempt)
+
+		tflog.Info(ctx, "no capacity for any capacity fallback, retrying", map[string]any{
+			"backoff": backoff.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			diags.Append(capacityTimeoutDiagnostic(capacityError, attempt+1))
+			return nil, diags
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// capacityTimeoutDiagnostic returns the error of a create timeout which expired
+// after the attempts without capacity for any capacity fallback.
+func capacityTimeoutDiagnostic(capacityError *ClientError, attempts int) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		capacityError.Kind.Summary(),
+		capacityError.Detail()+"\n\n"+
+			fmt.Sprintf("No capacity became available for any capacity fallback before the create timeout expired (%d attempts).", attempts),
+	)
+}
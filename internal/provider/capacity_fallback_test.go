@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCapacityFallbackCandidates(t *testing.T) {
+	candidates := capacityFallbackCandidates("vcpu-8_memory-48g_nvidia-h100-1", pointer("A"),
+		[]string{"vcpu-16_memory-96g_nvidia-h100-2", "vcpu-8_memory-48g_nvidia-h100-1"},
+		[]string{"B", "A"},
+	)
+
+	expected := []capacityCandidate{
+		{Type: "vcpu-8_memory-48g_nvidia-h100-1", PlacementOption: pointer("A")},
+		{Type: "vcpu-8_memory-48g_nvidia-h100-1", PlacementOption: pointer("B")},
+		{Type: "vcpu-16_memory-96g_nvidia-h100-2", PlacementOption: pointer("A")},
+		{Type: "vcpu-16_memory-96g_nvidia-h100-2", PlacementOption: pointer("B")},
+	}
+
+	if !reflect.DeepEqual(candidates, expected) {
+		t.Errorf("expected %+v, got %+v", expected, candidates)
+	}
+}
+
+func TestCapacityFallbackCandidatesWithoutPlacementOption(t *testing.T) {
+	candidates := capacityFallbackCandidates("vcpu-2_memory-4g", nil, nil, []string{"B"})
+
+	expected := []capacityCandidate{
+		{Type: "vcpu-2_memory-4g"},
+		{Type: "vcpu-2_memory-4g", PlacementOption: pointer("B")},
+	}
+
+	if !reflect.DeepEqual(candidates, expected) {
+		t.Errorf("expected %+v, got %+v", expected, candidates)
+	}
+}
+
+func TestCapacityRetryBackoff(t *testing.T) {
+	expected := []time.Duration{
+		30 * time.Second,
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		5 * time.Minute,
+		5 * time.Minute,
+	}
+
+	for retry, backoff := range expected {
+		if actual := capacityRetryBackoff(retry); actual != backoff {
+			t.Errorf("retry %d: expected %s, got %s", retry, backoff, actual)
+		}
+	}
+}
+
+func TestCreateInstanceWithCapacityFallbackTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The create timeout expires while the second candidate is tried
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if requests == 2 {
+			cancel()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code":"insufficient_capacity","message":"no capacity"}`))
+	})
+
+	r := &InstanceResource{}
+	r.client = client
+	candidates := []capacityCandidate{
+		{Type: "vcpu-8_memory-48g_nvidia-h100-1"},
+		{Type: "vcpu-16_memory-96g_nvidia-h100-2"},
+	}
+
+	response, diags := r.createInstanceWithCapacityFallback(ctx, sagadata.CreateInstanceJSONRequestBody{Name: "trainer"}, candidates, true)
+
+	if response != nil {
+		t.Errorf("expected no response, got %+v", response)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+
+	errors := diags.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("expected 1 error, got %v", diags)
+	}
+	if expected := ClientErrorInsufficientCapacity.Summary(); errors[0].Summary() != expected {
+		t.Errorf("expected summary %q, got %q", expected, errors[0].Summary())
+	}
+	if !strings.Contains(errors[0].Detail(), "before the create timeout expired (1 attempts)") {
+		t.Errorf("expected the attempts in the detail, got %q", errors[0].Detail())
+	}
+}
+
+func TestInstancePopulateKeepsCapacityFallback(t *testing.T) {
+	ctx := context.Background()
+
+	fallbackTypes, _ := types.ListValueFrom(ctx, types.StringType, []string{"vcpu-4_memory-12g"})
+	fallbackPlacementOptions, _ := types.ListValueFrom(ctx, types.StringType, []string{"B"})
+
+	instance := &sagadata.Instance{
+		Id:              "instance-id",
+		Type:            "vcpu-4_memory-12g",
+		PlacementOption: "B",
+	}
+
+	t.Run("fallback used", func(t *testing.T) {
+		data := InstanceResourceModel{
+			Type:            types.StringValue("vcpu-2_memory-4g"),
+			PlacementOption: types.StringValue("A"),
+			CapacityFallback: &InstanceCapacityFallbackModel{
+				Types:            fallbackTypes,
+				PlacementOptions: fallbackPlacementOptions,
+			},
+		}
+
+		if diags := data.PopulateFromClientResponse(ctx, instance); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		if data.Type.ValueString() != "vcpu-2_memory-4g" || data.SelectedType.ValueString() != "vcpu-4_memory-12g" {
+			t.Errorf("unexpected type %s and selected type %s", data.Type, data.SelectedType)
+		}
+		if data.PlacementOption.ValueString() != "A" || data.SelectedPlacementOption.ValueString() != "B" {
+			t.Errorf("unexpected placement option %s and selected placement option %s", data.PlacementOption, data.SelectedPlacementOption)
+		}
+	})
+
+	t.Run("changed outside of Terraform", func(t *testing.T) {
+		data := InstanceResourceModel{
+			Type:            types.StringValue("vcpu-2_memory-4g"),
+			PlacementOption: types.StringValue("A"),
+		}
+
+		if diags := data.PopulateFromClientResponse(ctx, instance); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		if data.Type.ValueString() != "vcpu-4_memory-12g" || data.PlacementOption.ValueString() != "B" {
+			t.Errorf("unexpected type %s and placement option %s", data.Type, data.PlacementOption)
+		}
+	})
+}
@@ -2,7 +2,9 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -30,6 +32,15 @@ type Client struct {
 	*sagadata.ClientWithResponses
 
 	PollingInterval time.Duration
+
+	// DefaultRegion The region of regional resources without a configured region.
+	DefaultRegion string
+
+	// Timeouts The timeouts of resources and data sources without configured timeouts.
+	Timeouts ClientTimeouts
+
+	// readCache Coalesces the reads of resources, nil if disabled.
+	readCache *readCache
 }
 
 func (c *Client) PollingWait(ctx context.Context) error {
@@ -44,6 +55,67 @@ func (c *Client) PollingWait(ctx context.Context) error {
 type ClientConfig struct {
 	sagadata.ClientConfig
 	PollingInterval time.Duration
+	DefaultRegion   string
+	Timeouts        ClientTimeouts
+	Transport       TransportConfig
+	RateLimit       RateLimitConfig
+
+	// ReadCacheTTL How long listed resources are used for reads, zero disables the read cache.
+	ReadCacheTTL time.Duration
+}
+
+// TransportConfig configures TLS and the proxy of the HTTP transport.
+type TransportConfig struct {
+	// CACertPEM Additional PEM encoded CA certificates to trust.
+	CACertPEM []byte
+
+	// ClientCertPEM and ClientKeyPEM The PEM encoded client certificate and key for mutual TLS.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+
+	InsecureSkipVerify bool
+
+	// ProxyURL The proxy for all requests, instead of the proxy of the environment.
+	ProxyURL *url.URL
+}
+
+// configure applies the configuration to the transport.
+func (c TransportConfig) configure(transport *http.Transport) error {
+	tlsConfig := transport.TLSClientConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+
+	if len(c.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(c.CACertPEM) {
+			return errors.New("no valid PEM encoded CA certificate found")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(c.ClientCertPEM) > 0 || len(c.ClientKeyPEM) > 0 {
+		certificate, err := tls.X509KeyPair(c.ClientCertPEM, c.ClientKeyPEM)
+		if err != nil {
+			return fmt.Errorf("invalid client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	tlsConfig.InsecureSkipVerify = c.InsecureSkipVerify
+	transport.TLSClientConfig = tlsConfig
+
+	if c.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(c.ProxyURL)
+	}
+
+	return nil
 }
 
 func NewClient(ctx context.Context, config ClientConfig) (*Client, error) {
@@ -52,6 +124,20 @@ func NewClient(ctx context.Context, config ClientConfig) (*Client, error) {
 	retryClient.Logger = ClientLogger{ctx: ctx}
 	retryClient.CheckRetry = RetryPolicy
 
+	transport, ok := retryClient.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected HTTP transport type %T", retryClient.HTTPClient.Transport)
+	}
+	if err := config.Transport.configure(transport); err != nil {
+		return nil, err
+	}
+	retryClient.HTTPClient.Transport = newRateLimitTransport(transport, config.RateLimit)
+
+	cache := newReadCache(config.ReadCacheTTL)
+	if cache != nil {
+		retryClient.HTTPClient.Transport = &readCacheTransport{next: retryClient.HTTPClient.Transport, cache: cache}
+	}
+
 	opts := []sagadata.ClientOption{
 		sagadata.WithHTTPClient(retryClient.StandardClient()),
 	}
@@ -64,6 +150,9 @@ func NewClient(ctx context.Context, config ClientConfig) (*Client, error) {
 	return &Client{
 		ClientWithResponses: client,
 		PollingInterval:     config.PollingInterval,
+		DefaultRegion:       config.DefaultRegion,
+		Timeouts:            config.Timeouts,
+		readCache:           cache,
 	}, nil
 }
 
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTransportConfigCACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	testCases := map[string]struct {
+		config      TransportConfig
+		expectError bool
+	}{
+		"untrusted": {
+			expectError: true,
+		},
+		"ca cert": {
+			config: TransportConfig{CACertPEM: caCertPEM},
+		},
+		"insecure skip verify": {
+			config: TransportConfig{InsecureSkipVerify: true},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			transport := &http.Transport{}
+			if err := testCase.config.configure(transport); err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if testCase.expectError {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestTransportConfigInvalid(t *testing.T) {
+	testCases := map[string]TransportConfig{
+		"invalid ca cert":     {CACertPEM: []byte("not a certificate")},
+		"invalid client cert": {ClientCertPEM: []byte("not a certificate"), ClientKeyPEM: []byte("not a key")},
+	}
+
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := config.configure(&http.Transport{}); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestTransportConfigClientCert(t *testing.T) {
+	clientCertPEM, clientKeyPEM := generateTestCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(clientCertPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	config := TransportConfig{
+		CACertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		ClientCertPEM: clientCertPEM,
+		ClientKeyPEM:  clientKeyPEM,
+	}
+
+	transport := &http.Transport{}
+	if err := config.configure(transport); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestTransportConfigProxy(t *testing.T) {
+	proxyURL, _ := url.Parse("http://proxy.example.com:3128")
+
+	transport := &http.Transport{}
+	if err := (TransportConfig{ProxyURL: proxyURL}).configure(transport); err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.sagadata.no", nil)
+	actual, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.String() != proxyURL.String() {
+		t.Errorf("expected proxy %q, got %q", proxyURL, actual)
+	}
+}
+
+// generateTestCertificate generates a self-signed client certificate and key.
+func generateTestCertificate(t *testing.T) (certPEM []byte, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
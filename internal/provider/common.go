@@ -4,8 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
 
 	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 )
 
 type ErrorResponse struct {
@@ -32,6 +36,224 @@ func generateClientErrorMessage(verb string, resp ErrorResponse) string {
 	}
 }
 
+// ClientErrorKind classifies the error responses of the API.
+type ClientErrorKind int
+
+const (
+	ClientErrorUnknown ClientErrorKind = iota
+	ClientErrorUnauthorized
+	ClientErrorNotFound
+	ClientErrorConflict
+	ClientErrorQuotaExceeded
+	ClientErrorInsufficientCapacity
+	ClientErrorValidation
+	ClientErrorRateLimited
+	ClientErrorServer
+)
+
+// Summary returns the diagnostic summary of the kind.
+func (k ClientErrorKind) Summary() string {
+	switch k {
+	case ClientErrorUnauthorized:
+		return "Unauthorized"
+	case ClientErrorNotFound:
+		return "Resource Not Found"
+	case ClientErrorConflict:
+		return "Resource Conflict"
+	case ClientErrorQuotaExceeded:
+		return "Quota Exceeded"
+	case ClientErrorInsufficientCapacity:
+		return "Insufficient Capacity"
+	case ClientErrorValidation:
+		return "Invalid Request"
+	case ClientErrorRateLimited:
+		return "Rate Limited"
+	case ClientErrorServer:
+		return "Saga Data API Error"
+	default:
+		return "Client Error"
+	}
+}
+
+// hint returns what can be done about errors of the kind.
+func (k ClientErrorKind) hint() string {
+	switch k {
+	case ClientErrorUnauthorized:
+		return "Check that the token is valid and has the permissions for this operation."
+	case ClientErrorNotFound:
+		return "The resource may have been deleted outside of Terraform."
+	case ClientErrorConflict:
+		return "The resource is in use by or conflicts with another resource, e.g. an attached volume or a duplicate name. Detach or rename it and retry."
+	case ClientErrorQuotaExceeded:
+		return "The quota of the account is exhausted. Delete unused resources or request a quota increase from Saga Data support."
+	case ClientErrorInsufficientCapacity:
+		return "The region has no capacity for the requested type right now. Retry later or choose another type or region."
+	case ClientErrorValidation:
+		return "The API rejected the configuration. Check the named attribute."
+	case ClientErrorRateLimited:
+		return "The requests exceeded the rate limit of the API. Configure requests_per_second on the provider to limit them."
+	case ClientErrorServer:
+		return "The Saga Data API failed to process the request. Retry later and contact Saga Data support with the request ID if the error persists."
+	default:
+		return ""
+	}
+}
+
+// ClientError is a classified error response of the API.
+type ClientError struct {
+	Kind ClientErrorKind
+
+	// Verb The operation, e.g. "create instance".
+	Verb string
+
+	// Field The field of the request the API names in the error, if any.
+	Field string
+
+	// FieldAttributes The attributes of the fields of the request, nil if the
+	// error does not belong to a request of the resource.
+	FieldAttributes map[string]string
+
+	// RequestId The request ID for support tickets, if the API returned one.
+	RequestId string
+
+	Response ErrorResponse
+}
+
+// Patterns of error messages which name a field, e.g. "name: must not be empty"
+// or "invalid value for field 'disk_size'".
+var clientErrorFieldRes = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bfield\s+["'\x60]?([a-z][a-z0-9_.\[\]]*)`),
+	regexp.MustCompile(`^([a-z][a-z0-9_.\[\]]*)\s*:\s`),
+	regexp.MustCompile(`["'\x60]([a-z][a-z0-9_.\[\]]*)["'\x60]\s+(?:is|must|should|has)\b`),
+}
+
+// NewClientError classifies an error response of the API. The field attributes
+// map the fields of the request to the attributes of the resource.
+func NewClientError(verb string, fieldAttributes map[string]string, resp ErrorResponse) *ClientError {
+	clientError := &ClientError{
+		Verb:            verb,
+		FieldAttributes: fieldAttributes,
+		Response:        resp,
+	}
+
+	var code, message string
+	if resp.Error != nil {
+		code, message = resp.Error.Code, resp.Error.Message
+	}
+
+	var statusCode int
+	if resp.HTTPResponse != nil {
+		statusCode = resp.HTTPResponse.StatusCode
+		clientError.RequestId = resp.HTTPResponse.Header.Get("X-Request-Id")
+	}
+
+	clientError.Kind = classifyClientError(code, statusCode)
+
+	for _, re := range clientErrorFieldRes {
+		if match := re.FindStringSubmatch(message); match != nil {
+			clientError.Field = match[1]
+			break
+		}
+	}
+
+	return clientError
+}
+
+// classifyClientError classifies by the error code and falls back to the status code.
+func classifyClientError(code string, statusCode int) ClientErrorKind {
+	normalized := strings.NewReplacer("_", "", "-", "", " ", "", ".", "").Replace(strings.ToLower(code))
+
+	containsAny := func(substrings ...string) bool {
+		for _, substring := range substrings {
+			if strings.Contains(normalized, substring) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case normalized == "":
+	case containsAny("quota"):
+		return ClientErrorQuotaExceeded
+	case containsAny("capacity", "outofstock", "nostock", "insufficientresources", "notavailable"):
+		return ClientErrorInsufficientCapacity
+	case containsAny("unauthorized", "unauthenticated", "forbidden", "invalidtoken", "permission"):
+		return ClientErrorUnauthorized
+	case containsAny("notfound"):
+		return ClientErrorNotFound
+	case containsAny("conflict", "inuse", "alreadyexists", "attached", "locked"):
+		return ClientErrorConflict
+	case containsAny("ratelimit", "toomanyrequests"):
+		return ClientErrorRateLimited
+	case containsAny("validation", "invalid", "badrequest"):
+		return ClientErrorValidation
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ClientErrorUnauthorized
+	case statusCode == http.StatusNotFound:
+		return ClientErrorNotFound
+	case statusCode == http.StatusConflict:
+		return ClientErrorConflict
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity:
+		return ClientErrorValidation
+	case statusCode == http.StatusTooManyRequests:
+		return ClientErrorRateLimited
+	case statusCode >= 500:
+		return ClientErrorServer
+	default:
+		return ClientErrorUnknown
+	}
+}
+
+func (e *ClientError) Error() string {
+	return generateClientErrorMessage(e.Verb, e.Response)
+}
+
+// Detail returns the diagnostic detail with a hint and the request ID.
+func (e *ClientError) Detail() string {
+	detail := e.Error()
+
+	if hint := e.Kind.hint(); hint != "" {
+		detail += "\n\n" + hint
+	}
+
+	if e.RequestId != "" {
+		detail += "\n\nRequest ID: " + e.RequestId
+	}
+
+	return detail
+}
+
+// AttributePath returns the path of the attribute the API names in the error, if any.
+func (e *ClientError) AttributePath() (path.Path, bool) {
+	field, _, _ := strings.Cut(e.Field, ".")
+	field, _, _ = strings.Cut(field, "[")
+
+	attribute, ok := e.FieldAttributes[field]
+	if !ok {
+		return path.Empty(), false
+	}
+
+	return path.Root(attribute), true
+}
+
+// Diagnostic returns the error as diagnostic, on the named attribute if any.
+func (e *ClientError) Diagnostic() diag.Diagnostic {
+	if attrPath, ok := e.AttributePath(); ok {
+		return diag.NewAttributeErrorDiagnostic(attrPath, e.Kind.Summary(), e.Detail())
+	}
+
+	return diag.NewErrorDiagnostic(e.Kind.Summary(), e.Detail())
+}
+
+// newClientErrorDiagnostic returns the classified diagnostic of an error response of the API.
+func newClientErrorDiagnostic(verb string, fieldAttributes map[string]string, resp ErrorResponse) diag.Diagnostic {
+	return NewClientError(verb, fieldAttributes, resp).Diagnostic()
+}
+
 func sliceStringify[T ~string](arr []T) []string {
 	ret := make([]string, len(arr))
 	for i, value := range arr {
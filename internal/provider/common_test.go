@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestNewClientError(t *testing.T) {
+	newResponse := func(statusCode int, code string, message string) ErrorResponse {
+		return ErrorResponse{
+			HTTPResponse: &http.Response{
+				Status:     http.StatusText(statusCode),
+				StatusCode: statusCode,
+				Header:     http.Header{"X-Request-Id": []string{"req-123"}},
+			},
+			Error: &sagadata.Error{Code: code, Message: message},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		fieldAttributes map[string]string
+		response        ErrorResponse
+		kind            ClientErrorKind
+		path            *path.Path
+	}{
+		{
+			name:     "quota by code",
+			response: newResponse(http.StatusBadRequest, "QUOTA_EXCEEDED", "The instance quota is exhausted"),
+			kind:     ClientErrorQuotaExceeded,
+		},
+		{
+			name:     "capacity by code",
+			response: newResponse(http.StatusConflict, "insufficient-capacity", "No capacity for vcpu-4_memory-12g"),
+			kind:     ClientErrorInsufficientCapacity,
+		},
+		{
+			name:            "validation with field",
+			fieldAttributes: instanceErrorFieldAttributes,
+			response:        newResponse(http.StatusBadRequest, "VALIDATION_ERROR", "disk_size: must be at least 80"),
+			kind:            ClientErrorValidation,
+			path:            pointer(path.Root("disk_size")),
+		},
+		{
+			name:            "validation with renamed field",
+			fieldAttributes: instanceErrorFieldAttributes,
+			response:        newResponse(http.StatusUnprocessableEntity, "", "invalid value for field 'ssh_keys[0]'"),
+			kind:            ClientErrorValidation,
+			path:            pointer(path.Root("ssh_key_ids")),
+		},
+		{
+			name:            "validation with unknown field",
+			fieldAttributes: instanceErrorFieldAttributes,
+			response:        newResponse(http.StatusBadRequest, "INVALID_ARGUMENT", "foo: must not be empty"),
+			kind:            ClientErrorValidation,
+		},
+		{
+			name:            "validation with field of another resource",
+			fieldAttributes: instanceErrorFieldAttributes,
+			response:        newResponse(http.StatusBadRequest, "VALIDATION_ERROR", "value: must be a valid public key"),
+			kind:            ClientErrorValidation,
+		},
+		{
+			name:            "validation with field of the resource",
+			fieldAttributes: sshKeyErrorFieldAttributes,
+			response:        newResponse(http.StatusBadRequest, "VALIDATION_ERROR", "value: must be a valid public key"),
+			kind:            ClientErrorValidation,
+			path:            pointer(path.Root("public_key")),
+		},
+		{
+			name:     "validation without request fields",
+			response: newResponse(http.StatusBadRequest, "VALIDATION_ERROR", "size: must be at least 1"),
+			kind:     ClientErrorValidation,
+		},
+		{
+			name:     "in use by code",
+			response: newResponse(http.StatusBadRequest, "VOLUME_IN_USE", "The volume is attached to an instance"),
+			kind:     ClientErrorConflict,
+		},
+		{
+			name:     "not found by status",
+			response: newResponse(http.StatusNotFound, "", "Not found"),
+			kind:     ClientErrorNotFound,
+		},
+		{
+			name:     "unauthorized by status",
+			response: newResponse(http.StatusForbidden, "", "Forbidden"),
+			kind:     ClientErrorUnauthorized,
+		},
+		{
+			name:     "server error by status",
+			response: newResponse(http.StatusBadGateway, "", "Bad gateway"),
+			kind:     ClientErrorServer,
+		},
+		{
+			name:     "unknown",
+			response: newResponse(http.StatusTeapot, "", ""),
+			kind:     ClientErrorUnknown,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientError := NewClientError("create instance", tc.fieldAttributes, tc.response)
+
+			if clientError.Kind != tc.kind {
+				t.Errorf("expected kind %q, got %q", tc.kind.Summary(), clientError.Kind.Summary())
+			}
+
+			if clientError.RequestId != "req-123" {
+				t.Errorf("expected request ID req-123, got %q", clientError.RequestId)
+			}
+
+			d := clientError.Diagnostic()
+			if d.Severity() != diag.SeverityError {
+				t.Errorf("expected an error diagnostic, got %v", d.Severity())
+			}
+			if d.Summary() != tc.kind.Summary() {
+				t.Errorf("expected summary %q, got %q", tc.kind.Summary(), d.Summary())
+			}
+			if !strings.Contains(d.Detail(), "Request ID: req-123") {
+				t.Errorf("expected the request ID in the detail, got %q", d.Detail())
+			}
+
+			withPath, ok := d.(diag.DiagnosticWithPath)
+			switch {
+			case tc.path == nil && ok:
+				t.Errorf("expected no attribute path, got %s", withPath.Path())
+			case tc.path != nil && !ok:
+				t.Errorf("expected attribute path %s, got none", *tc.path)
+			case tc.path != nil && !withPath.Path().Equal(*tc.path):
+				t.Errorf("expected attribute path %s, got %s", *tc.path, withPath.Path())
+			}
+		})
+	}
+}
+
+func TestClientErrorWithoutResponse(t *testing.T) {
+	clientError := NewClientError("read volume", nil, ErrorResponse{
+		Body: []byte("upstream connect error"),
+		HTTPResponse: &http.Response{
+			Status:     "418 I'm a teapot",
+			StatusCode: http.StatusTeapot,
+			Header:     http.Header{},
+		},
+	})
+
+	if clientError.Kind != ClientErrorUnknown {
+		t.Errorf("expected unknown kind, got %q", clientError.Kind.Summary())
+	}
+
+	if d := clientError.Diagnostic(); d.Summary() != "Client Error" || strings.Contains(d.Detail(), "Request ID") {
+		t.Errorf("unexpected diagnostic %q: %q", d.Summary(), d.Detail())
+	}
+}
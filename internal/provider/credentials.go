@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const defaultProfile = "default"
+
+// tokenCommandTimeout The time the token command may take, e.g. waiting for
+// a password manager to be unlocked, before it is killed.
+var tokenCommandTimeout = 30 * time.Second
+
+// credentialsProfile is a named profile of the credentials file.
+type credentialsProfile struct {
+	Endpoint      string
+	Token         string
+	TokenCommand  string
+	DefaultRegion string
+}
+
+// defaultCredentialsFile returns the path of the credentials file, which is
+// "$XDG_CONFIG_HOME/sagadata/credentials" or "~/.config/sagadata/credentials".
+func defaultCredentialsFile() (string, error) {
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return filepath.Join(configHome, "sagadata", "credentials"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "sagadata", "credentials"), nil
+}
+
+// readCredentialsProfile reads a profile of the credentials file. A missing
+// file or profile is only an error when required is set.
+func readCredentialsProfile(path string, name string, required bool) (*credentialsProfile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !required {
+			return &credentialsProfile{}, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	profiles, err := parseCredentials(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		if !required {
+			return &credentialsProfile{}, nil
+		}
+		return nil, fmt.Errorf("%s: profile %q not found", path, name)
+	}
+
+	return profile, nil
+}
+
+// parseCredentials parses the profiles of a credentials file in the INI format:
+//
+//	[default]
+//	endpoint = https://api.sagadata.no
+//	token = ...
+//
+//	[staging]
+//	token_command = pass show sagadata/staging
+//	default_region = NORD-NO-KRS-1
+func parseCredentials(r io.Reader) (map[string]*credentialsProfile, error) {
+	profiles := map[string]*credentialsProfile{}
+
+	var profile *credentialsProfile
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", line)
+			}
+
+			profile = &credentialsProfile{}
+			profiles[name] = profile
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected a profile or key = value", line)
+		}
+		if profile == nil {
+			return nil, fmt.Errorf("line %d: key outside of a profile", line)
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "endpoint":
+			profile.Endpoint = value
+		case "token":
+			profile.Token = value
+		case "token_command":
+			profile.TokenCommand = value
+		case "default_region":
+			profile.DefaultRegion = value
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q", line, key)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// runTokenCommand runs the command through the shell and returns its output
+// without surrounding whitespace as the token. The command is killed when it
+// does not finish within the tokenCommandTimeout.
+func runTokenCommand(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// Do not wait for processes started by the killed shell which keep the output open
+	cmd.WaitDelay = time.Second
+
+	output, err := cmd.Output()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("the command did not finish within %s and was killed", tokenCommandTimeout)
+	}
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%w: %s", err, message)
+		}
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", errors.New("the command returned an empty token")
+	}
+
+	return token, nil
+}
+
+// validateCredentials verifies the token with a lightweight request to the API.
+func validateCredentials(ctx context.Context, client *Client, tokenSource string) (diags diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, client.DefaultTimeouts("").Read)
+	defer cancel()
+
+	response, err := client.ListSSHKeysPaginatedWithResponse(ctx, &sagadata.ListSSHKeysPaginatedParams{
+		Page:    pointer(1),
+		PerPage: pointer(1),
+	})
+	if err != nil {
+		diags.AddError(
+			"Unable to Validate Saga Data API Credentials",
+			generateErrorMessage("credentials validation", err)+"\n\n"+
+				"Set skip_credentials_validation to skip the validation, e.g. for offline plans.",
+		)
+		return
+	}
+
+	switch response.StatusCode() {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		diags.AddAttributeError(
+			path.Root("token"),
+			"Invalid or Expired Saga Data API token",
+			fmt.Sprintf("The Saga Data API rejected the token from %s with %q. ", tokenSource, response.HTTPResponse.Status)+
+				"Check that the token is complete, has not expired or been revoked, and belongs to the account of the endpoint. "+
+				"A new token can be generated in the Saga Data console.",
+		)
+	default:
+		diags.AddError(
+			"Unable to Validate Saga Data API Credentials",
+			NewClientError("credentials validation", nil, ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}).Detail(),
+		)
+	}
+
+	return
+}
@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseCredentials(t *testing.T) {
+	profiles, err := parseCredentials(strings.NewReader(`
+# Production
+[default]
+token = prod-token
+default_region = NORD-NO-KRS-1
+
+[staging]
+endpoint = https://staging.example.com
+token_command = pass show sagadata/staging
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]credentialsProfile{
+		"default": {
+			Token:         "prod-token",
+			DefaultRegion: "NORD-NO-KRS-1",
+		},
+		"staging": {
+			Endpoint:     "https://staging.example.com",
+			TokenCommand: "pass show sagadata/staging",
+		},
+	}
+
+	if len(profiles) != len(expected) {
+		t.Fatalf("expected %d profiles, got %d", len(expected), len(profiles))
+	}
+
+	for name, profile := range expected {
+		if actual, ok := profiles[name]; !ok || *actual != profile {
+			t.Errorf("expected profile %q to be %+v, got %+v", name, profile, actual)
+		}
+	}
+}
+
+func TestParseCredentialsInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"key outside of a profile": "token = abc",
+		"unknown key":              "[default]\nsecret = abc",
+		"missing value":            "[default]\ntoken",
+		"empty profile name":       "[]",
+	}
+
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseCredentials(strings.NewReader(content)); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestReadCredentialsProfile(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+
+	err := os.WriteFile(credentialsFile, []byte("[staging]\ntoken = staging-token\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := readCredentialsProfile(credentialsFile, "staging", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Token != "staging-token" {
+		t.Errorf("expected token %q, got %q", "staging-token", profile.Token)
+	}
+
+	if _, err := readCredentialsProfile(credentialsFile, "prod", true); err == nil {
+		t.Errorf("expected an error for a missing required profile")
+	}
+
+	if _, err := readCredentialsProfile(credentialsFile, defaultProfile, false); err != nil {
+		t.Errorf("unexpected error for a missing optional profile: %v", err)
+	}
+
+	if _, err := readCredentialsProfile(filepath.Join(t.TempDir(), "missing"), defaultProfile, false); err != nil {
+		t.Errorf("unexpected error for a missing optional credentials file: %v", err)
+	}
+}
+
+func TestRunTokenCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	ctx := context.Background()
+
+	token, err := runTokenCommand(ctx, "echo '  secret-token  '")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "secret-token" {
+		t.Errorf("expected token %q, got %q", "secret-token", token)
+	}
+
+	if _, err := runTokenCommand(ctx, "echo 'locked' >&2; exit 1"); err == nil || !strings.Contains(err.Error(), "locked") {
+		t.Errorf("expected an error containing the output of the command, got: %v", err)
+	}
+
+	if _, err := runTokenCommand(ctx, "true"); err == nil {
+		t.Errorf("expected an error for an empty token")
+	}
+}
+
+func TestRunTokenCommandTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	timeout := tokenCommandTimeout
+	tokenCommandTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { tokenCommandTimeout = timeout })
+
+	start := time.Now()
+
+	_, err := runTokenCommand(context.Background(), "sleep 10; echo secret-token")
+	if err == nil || !strings.Contains(err.Error(), "did not finish within 100ms") {
+		t.Errorf("expected a timeout error, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the command to be killed, took %s", elapsed)
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	testCases := map[string]struct {
+		status          int
+		endpoint        string
+		expectError     bool
+		expectAttribute bool
+	}{
+		"valid": {
+			status: http.StatusOK,
+		},
+		"unauthorized": {
+			status:          http.StatusUnauthorized,
+			expectError:     true,
+			expectAttribute: true,
+		},
+		"forbidden": {
+			status:          http.StatusForbidden,
+			expectError:     true,
+			expectAttribute: true,
+		},
+		"not found": {
+			status:      http.StatusNotFound,
+			expectError: true,
+		},
+		"server error": {
+			status:      http.StatusInternalServerError,
+			expectError: true,
+		},
+		"transport error": {
+			endpoint:    "ftp://127.0.0.1",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer test-token" {
+					t.Errorf("expected the token to be sent, got %q", r.Header.Get("Authorization"))
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(testCase.status)
+				if testCase.status == http.StatusOK {
+					_, _ = w.Write([]byte(`{"ssh_keys": []}`))
+				} else {
+					_, _ = w.Write([]byte(`{"code": "error", "message": "request failed"}`))
+				}
+			})
+
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			endpoint := server.URL
+			if testCase.endpoint != "" {
+				endpoint = testCase.endpoint
+			}
+
+			client, err := NewClient(ctx, ClientConfig{
+				ClientConfig: sagadata.ClientConfig{
+					Endpoint: endpoint,
+					Token:    "test-token",
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diags := validateCredentials(ctx, client, "the token attribute")
+
+			if diags.HasError() != testCase.expectError {
+				t.Fatalf("expected error %t, got %v", testCase.expectError, diags)
+			}
+
+			for _, d := range diags.Errors() {
+				withPath, ok := d.(diag.DiagnosticWithPath)
+				if ok != testCase.expectAttribute {
+					t.Errorf("expected attribute error %t, got %v", testCase.expectAttribute, d)
+				}
+				if ok && !withPath.Path().Equal(path.Root("token")) {
+					t.Errorf("expected an error on token, got %s", withPath.Path())
+				}
+			}
+		})
+	}
+}
+
+func TestProviderConfigureSkipCredentialsValidation(t *testing.T) {
+	testCases := map[string]struct {
+		skip             bool
+		expectedRequests int
+	}{
+		"validate": {
+			expectedRequests: 1,
+		},
+		"skip": {
+			skip:             true,
+			expectedRequests: 0,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"ssh_keys": []}`))
+			}))
+			defer server.Close()
+
+			t.Setenv("SAGADATA_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "missing"))
+
+			p := New("test")()
+
+			schemaResp := &fwprovider.SchemaResponse{}
+			p.Schema(ctx, fwprovider.SchemaRequest{}, schemaResp)
+
+			config := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+
+			var diags diag.Diagnostics
+			diags.Append(config.SetAttribute(ctx, path.Root("endpoint"), server.URL)...)
+			diags.Append(config.SetAttribute(ctx, path.Root("token"), "test-token")...)
+			diags.Append(config.SetAttribute(ctx, path.Root("skip_credentials_validation"), testCase.skip)...)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := &fwprovider.ConfigureResponse{}
+			p.Configure(ctx, fwprovider.ConfigureRequest{
+				Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+			}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if requests != testCase.expectedRequests {
+				t.Errorf("expected %d requests, got %d", testCase.expectedRequests, requests)
+			}
+		})
+	}
+}
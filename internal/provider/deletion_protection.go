@@ -0,0 +1,397 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sagadata-public/terraform-provider-sagadata/internal/defaultplanmodifier"
+	"github.com/sagadata-public/terraform-provider-sagadata/internal/resourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// deletionProtectionAttribute returns the deletion_protection attribute of a
+// resource of the kind.
+func deletionProtectionAttribute(ctx context.Context, kind string) schema.Attribute {
+	return resourceenhancer.Attribute(ctx, schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("Flag to prevent the %s from being deleted, including replacements. ", kind) +
+			"It has to be turned off and applied before the resource can be deleted.",
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.Bool{
+			defaultplanmodifier.Bool(false),
+		},
+	})
+}
+
+// modifyPlanDeletionProtection warns when a protected resource is planned to be
+// destroyed or replaced, as the apply is going to fail.
+func modifyPlanDeletionProtection(ctx context.Context, kind string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if !req.Plan.Raw.IsNull() && len(resp.RequiresReplace) == 0 {
+		replace, diags := plannedReplacement(ctx, req)
+		resp.Diagnostics.Append(diags...)
+		if !replace || resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var deletionProtection types.Bool
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if resp.Diagnostics.HasError() || !deletionProtection.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("deletion_protection"),
+		"Deletion Protection Enabled",
+		fmt.Sprintf("The %s is planned to be destroyed or replaced but has deletion_protection enabled, so the apply is going to fail. ", kind)+
+			"Turn off deletion_protection and apply before destroying it.",
+	)
+}
+
+// plannedReplacement returns whether a plan modifier of an attribute, including
+// nested attributes, requires the resource to be replaced. The resource-level
+// ModifyPlan does not receive the replacements of the attribute plan modifiers,
+// so they are run again. The framework only calls ModifyPlan when they did not
+// return errors, and their warnings were already reported, so only errors are
+// returned.
+func plannedReplacement(ctx context.Context, req resource.ModifyPlanRequest) (bool, diag.Diagnostics) {
+	resourceSchema, ok := req.Plan.Schema.(schema.Schema)
+	if !ok {
+		return false, nil
+	}
+
+	replace, diags := attributesRequireReplace(ctx, req, path.Empty(), resourceSchema.Attributes)
+
+	return replace, diags.Errors()
+}
+
+// attributesRequireReplace returns whether a plan modifier of the attributes
+// below the parent path requires the resource to be replaced.
+func attributesRequireReplace(ctx context.Context, req resource.ModifyPlanRequest, parent path.Path, attributes map[string]schema.Attribute) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	for name, attribute := range attributes {
+		replace, attributeDiags := attributeRequiresReplace(ctx, req, parent.AtName(name), attribute)
+		diags.Append(attributeDiags...)
+		if replace || diags.HasError() {
+			return replace, diags
+		}
+	}
+
+	return false, diags
+}
+
+// attributeRequiresReplace returns whether a plan modifier of the attribute at
+// the path, or of its nested attributes, requires the resource to be replaced.
+func attributeRequiresReplace(ctx context.Context, req resource.ModifyPlanRequest, p path.Path, attribute schema.Attribute) (bool, diag.Diagnostics) {
+	switch attribute := attribute.(type) {
+	case schema.BoolAttribute:
+		return modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanBool)
+	case schema.Float64Attribute:
+		return modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanFloat64)
+	case schema.Int64Attribute:
+		return modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanInt64)
+	case schema.ListAttribute:
+		return modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanList)
+	case schema.MapAttribute:
+		return modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanMap)
+	case schema.NumberAttribute:
+		return modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanNumber)
+	case schema.ObjectAttribute:
+		return modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanObject)
+	case schema.SetAttribute:
+		return modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanSet)
+	case schema.StringAttribute:
+		return modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanString)
+	case schema.SingleNestedAttribute:
+		replace, diags := modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanObject)
+		return nestedRequireReplace(ctx, req, p, attribute.Attributes, replace, diags)
+	case schema.ListNestedAttribute:
+		replace, diags := modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanList)
+		return nestedRequireReplace(ctx, req, p, attribute.NestedObject.Attributes, replace, diags)
+	case schema.SetNestedAttribute:
+		replace, diags := modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanSet)
+		return nestedRequireReplace(ctx, req, p, attribute.NestedObject.Attributes, replace, diags)
+	case schema.MapNestedAttribute:
+		replace, diags := modifiersRequireReplace(ctx, req, p, attribute.PlanModifiers, modifyPlanMap)
+		return nestedRequireReplace(ctx, req, p, attribute.NestedObject.Attributes, replace, diags)
+	default:
+		return false, nil
+	}
+}
+
+// nestedRequireReplace returns whether a plan modifier of the nested
+// attributes of each planned object of the nested attribute at the path
+// requires the resource to be replaced, unless the plan modifiers of the
+// nested attribute itself already did or failed.
+func nestedRequireReplace(ctx context.Context, req resource.ModifyPlanRequest, p path.Path, attributes map[string]schema.Attribute, replace bool, diags diag.Diagnostics) (bool, diag.Diagnostics) {
+	if replace || diags.HasError() {
+		return replace, diags
+	}
+
+	// Read the value generically, as nested attributes such as timeouts
+	// have custom types
+	var value attr.Value
+	diags.Append(req.Plan.GetAttribute(ctx, p, &value)...)
+	if diags.HasError() || value.IsNull() || value.IsUnknown() {
+		return false, diags
+	}
+
+	var objectPaths path.Paths
+	switch value := value.(type) {
+	case basetypes.ObjectValuable:
+		objectPaths = append(objectPaths, p)
+	case basetypes.ListValuable:
+		list, listDiags := value.ToListValue(ctx)
+		diags.Append(listDiags...)
+		for i := range list.Elements() {
+			objectPaths = append(objectPaths, p.AtListIndex(i))
+		}
+	case basetypes.SetValuable:
+		set, setDiags := value.ToSetValue(ctx)
+		diags.Append(setDiags...)
+		for _, element := range set.Elements() {
+			objectPaths = append(objectPaths, p.AtSetValue(element))
+		}
+	case basetypes.MapValuable:
+		elements, mapDiags := value.ToMapValue(ctx)
+		diags.Append(mapDiags...)
+		for key := range elements.Elements() {
+			objectPaths = append(objectPaths, p.AtMapKey(key))
+		}
+	}
+
+	for _, objectPath := range objectPaths {
+		replace, objectDiags := attributesRequireReplace(ctx, req, objectPath, attributes)
+		diags.Append(objectDiags...)
+		if replace || diags.HasError() {
+			return replace, diags
+		}
+	}
+
+	return false, diags
+}
+
+// planModifierValues are the values of the attribute at the path to run its
+// plan modifiers with.
+type planModifierValues[T attr.Value] struct {
+	req  resource.ModifyPlanRequest
+	path path.Path
+
+	config T
+	plan   T
+	state  T
+}
+
+// modifiersRequireReplace runs the plan modifiers of the attribute at the path
+// with modify and returns whether one requires the resource to be replaced.
+func modifiersRequireReplace[T attr.Value, M any](ctx context.Context, req resource.ModifyPlanRequest, p path.Path, modifiers []M, modify func(ctx context.Context, modifier M, values planModifierValues[T]) (bool, diag.Diagnostics)) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if len(modifiers) == 0 {
+		return false, diags
+	}
+
+	values := planModifierValues[T]{req: req, path: p}
+
+	diags.Append(req.Config.GetAttribute(ctx, p, &values.config)...)
+	diags.Append(req.Plan.GetAttribute(ctx, p, &values.plan)...)
+	diags.Append(req.State.GetAttribute(ctx, p, &values.state)...)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	for _, modifier := range modifiers {
+		replace, modifierDiags := modify(ctx, modifier, values)
+		diags.Append(modifierDiags...)
+		if replace || diags.HasError() {
+			return replace, diags
+		}
+	}
+
+	return false, diags
+}
+
+// modifyPlanBool and the functions below run a plan modifier of their value
+// type and return whether it requires the resource to be replaced.
+func modifyPlanBool(ctx context.Context, modifier planmodifier.Bool, values planModifierValues[types.Bool]) (bool, diag.Diagnostics) {
+	resp := &planmodifier.BoolResponse{PlanValue: values.plan}
+	modifier.PlanModifyBool(ctx, planmodifier.BoolRequest{
+		Path:           values.path,
+		PathExpression: values.path.Expression(),
+		Config:         values.req.Config,
+		ConfigValue:    values.config,
+		Plan:           values.req.Plan,
+		PlanValue:      values.plan,
+		State:          values.req.State,
+		StateValue:     values.state,
+		Private:        values.req.Private,
+	}, resp)
+
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+func modifyPlanFloat64(ctx context.Context, modifier planmodifier.Float64, values planModifierValues[types.Float64]) (bool, diag.Diagnostics) {
+	resp := &planmodifier.Float64Response{PlanValue: values.plan}
+	modifier.PlanModifyFloat64(ctx, planmodifier.Float64Request{
+		Path:           values.path,
+		PathExpression: values.path.Expression(),
+		Config:         values.req.Config,
+		ConfigValue:    values.config,
+		Plan:           values.req.Plan,
+		PlanValue:      values.plan,
+		State:          values.req.State,
+		StateValue:     values.state,
+		Private:        values.req.Private,
+	}, resp)
+
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+func modifyPlanInt64(ctx context.Context, modifier planmodifier.Int64, values planModifierValues[types.Int64]) (bool, diag.Diagnostics) {
+	resp := &planmodifier.Int64Response{PlanValue: values.plan}
+	modifier.PlanModifyInt64(ctx, planmodifier.Int64Request{
+		Path:           values.path,
+		PathExpression: values.path.Expression(),
+		Config:         values.req.Config,
+		ConfigValue:    values.config,
+		Plan:           values.req.Plan,
+		PlanValue:      values.plan,
+		State:          values.req.State,
+		StateValue:     values.state,
+		Private:        values.req.Private,
+	}, resp)
+
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+func modifyPlanList(ctx context.Context, modifier planmodifier.List, values planModifierValues[types.List]) (bool, diag.Diagnostics) {
+	resp := &planmodifier.ListResponse{PlanValue: values.plan}
+	modifier.PlanModifyList(ctx, planmodifier.ListRequest{
+		Path:           values.path,
+		PathExpression: values.path.Expression(),
+		Config:         values.req.Config,
+		ConfigValue:    values.config,
+		Plan:           values.req.Plan,
+		PlanValue:      values.plan,
+		State:          values.req.State,
+		StateValue:     values.state,
+		Private:        values.req.Private,
+	}, resp)
+
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+func modifyPlanMap(ctx context.Context, modifier planmodifier.Map, values planModifierValues[types.Map]) (bool, diag.Diagnostics) {
+	resp := &planmodifier.MapResponse{PlanValue: values.plan}
+	modifier.PlanModifyMap(ctx, planmodifier.MapRequest{
+		Path:           values.path,
+		PathExpression: values.path.Expression(),
+		Config:         values.req.Config,
+		ConfigValue:    values.config,
+		Plan:           values.req.Plan,
+		PlanValue:      values.plan,
+		State:          values.req.State,
+		StateValue:     values.state,
+		Private:        values.req.Private,
+	}, resp)
+
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+func modifyPlanNumber(ctx context.Context, modifier planmodifier.Number, values planModifierValues[types.Number]) (bool, diag.Diagnostics) {
+	resp := &planmodifier.NumberResponse{PlanValue: values.plan}
+	modifier.PlanModifyNumber(ctx, planmodifier.NumberRequest{
+		Path:           values.path,
+		PathExpression: values.path.Expression(),
+		Config:         values.req.Config,
+		ConfigValue:    values.config,
+		Plan:           values.req.Plan,
+		PlanValue:      values.plan,
+		State:          values.req.State,
+		StateValue:     values.state,
+		Private:        values.req.Private,
+	}, resp)
+
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+func modifyPlanObject(ctx context.Context, modifier planmodifier.Object, values planModifierValues[types.Object]) (bool, diag.Diagnostics) {
+	resp := &planmodifier.ObjectResponse{PlanValue: values.plan}
+	modifier.PlanModifyObject(ctx, planmodifier.ObjectRequest{
+		Path:           values.path,
+		PathExpression: values.path.Expression(),
+		Config:         values.req.Config,
+		ConfigValue:    values.config,
+		Plan:           values.req.Plan,
+		PlanValue:      values.plan,
+		State:          values.req.State,
+		StateValue:     values.state,
+		Private:        values.req.Private,
+	}, resp)
+
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+func modifyPlanSet(ctx context.Context, modifier planmodifier.Set, values planModifierValues[types.Set]) (bool, diag.Diagnostics) {
+	resp := &planmodifier.SetResponse{PlanValue: values.plan}
+	modifier.PlanModifySet(ctx, planmodifier.SetRequest{
+		Path:           values.path,
+		PathExpression: values.path.Expression(),
+		Config:         values.req.Config,
+		ConfigValue:    values.config,
+		Plan:           values.req.Plan,
+		PlanValue:      values.plan,
+		State:          values.req.State,
+		StateValue:     values.state,
+		Private:        values.req.Private,
+	}, resp)
+
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+func modifyPlanString(ctx context.Context, modifier planmodifier.String, values planModifierValues[types.String]) (bool, diag.Diagnostics) {
+	resp := &planmodifier.StringResponse{PlanValue: values.plan}
+	modifier.PlanModifyString(ctx, planmodifier.StringRequest{
+		Path:           values.path,
+		PathExpression: values.path.Expression(),
+		Config:         values.req.Config,
+		ConfigValue:    values.config,
+		Plan:           values.req.Plan,
+		PlanValue:      values.plan,
+		State:          values.req.State,
+		StateValue:     values.state,
+		Private:        values.req.Private,
+	}, resp)
+
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+// checkDeletionProtection returns an error when the resource is protected from
+// being deleted.
+func checkDeletionProtection(kind string, id string, deletionProtection types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if deletionProtection.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Deletion Protection Enabled",
+			fmt.Sprintf("The %s resource with id %q cannot be deleted because deletion_protection is enabled. ", kind, id)+
+				"Turn off deletion_protection and apply before deleting it.",
+		)
+	}
+
+	return diags
+}
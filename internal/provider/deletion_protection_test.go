@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sagadata-public/terraform-provider-sagadata/internal/decreaseplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCheckDeletionProtection(t *testing.T) {
+	testCases := map[string]struct {
+		deletionProtection types.Bool
+		expectError        bool
+	}{
+		"enabled":  {types.BoolValue(true), true},
+		"disabled": {types.BoolValue(false), false},
+		"null":     {types.BoolNull(), false},
+	}
+
+	for name, testCase := range testCases {
+		diags := checkDeletionProtection("instance", "instance-id", testCase.deletionProtection)
+		if diags.HasError() != testCase.expectError {
+			t.Errorf("%s: expected error %t, got %v", name, testCase.expectError, diags)
+		}
+	}
+}
+
+func TestModifyPlanDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewVolumeResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	// newState returns a state of a volume with the given name and type.
+	newState := func(t *testing.T, name string, volumeType string, deletionProtection bool) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+
+		var diags diag.Diagnostics
+		diags.Append(state.SetAttribute(ctx, path.Root("name"), name)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("region"), "NORD-NO-KRS-1")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("type"), volumeType)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("deletion_protection"), deletionProtection)...)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		return state
+	}
+
+	testCases := map[string]struct {
+		deletionProtection bool
+		destroy            bool
+		planName           string
+		planType           string
+		expectWarning      bool
+	}{
+		"destroy": {
+			deletionProtection: true,
+			destroy:            true,
+			expectWarning:      true,
+		},
+		"replacement": {
+			deletionProtection: true,
+			planName:           "data",
+			planType:           "ssd",
+			expectWarning:      true,
+		},
+		"update": {
+			deletionProtection: true,
+			planName:           "renamed",
+			planType:           "hdd",
+		},
+		"unprotected destroy": {
+			destroy: true,
+		},
+		"unprotected replacement": {
+			planName: "data",
+			planType: "ssd",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := newState(t, "data", "hdd", testCase.deletionProtection)
+
+			plan := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if !testCase.destroy {
+				plan = newState(t, testCase.planName, testCase.planType, testCase.deletionProtection)
+			}
+
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				State:  state,
+			}
+			resp := &fwresource.ModifyPlanResponse{
+				Plan: req.Plan,
+			}
+
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if warning := resp.Diagnostics.WarningsCount() > 0; warning != testCase.expectWarning {
+				t.Errorf("expected warning %t, got %v", testCase.expectWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPlannedReplacement(t *testing.T) {
+	ctx := context.Background()
+
+	nestedAttributes := map[string]schema.Attribute{
+		"value": schema.StringAttribute{
+			Optional: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+	}
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					decreaseplanmodifier.Int64(path.Root("on_size_decrease")),
+				},
+			},
+			"on_size_decrease": schema.StringAttribute{Optional: true},
+			"single":           schema.SingleNestedAttribute{Optional: true, Attributes: nestedAttributes},
+			"list":             schema.ListNestedAttribute{Optional: true, NestedObject: schema.NestedAttributeObject{Attributes: nestedAttributes}},
+			"set":              schema.SetNestedAttribute{Optional: true, NestedObject: schema.NestedAttributeObject{Attributes: nestedAttributes}},
+			"map":              schema.MapNestedAttribute{Optional: true, NestedObject: schema.NestedAttributeObject{Attributes: nestedAttributes}},
+			"timeouts":         timeouts.AttributesAll(ctx),
+		},
+	}
+	testType := testSchema.Type().TerraformType(ctx).(tftypes.Object)
+	nestedType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"value": tftypes.String}}
+
+	// newRaw returns an object of the test schema with the values of the
+	// attributes, which default to "a", and the size.
+	newRaw := func(values map[string]string, size int64) tftypes.Value {
+		value := func(name string) tftypes.Value {
+			if value, ok := values[name]; ok {
+				return tftypes.NewValue(tftypes.String, value)
+			}
+			return tftypes.NewValue(tftypes.String, "a")
+		}
+		nested := func(name string) tftypes.Value {
+			return tftypes.NewValue(nestedType, map[string]tftypes.Value{"value": value(name)})
+		}
+
+		timeoutsType := testType.AttributeTypes["timeouts"].(tftypes.Object)
+		timeoutValues := map[string]tftypes.Value{}
+		for name := range timeoutsType.AttributeTypes {
+			timeoutValues[name] = tftypes.NewValue(tftypes.String, nil)
+		}
+
+		return tftypes.NewValue(testType, map[string]tftypes.Value{
+			"name":             value("name"),
+			"size":             tftypes.NewValue(tftypes.Number, size),
+			"on_size_decrease": tftypes.NewValue(tftypes.String, nil),
+			"single":           nested("single"),
+			"list":             tftypes.NewValue(testType.AttributeTypes["list"], []tftypes.Value{nested("list")}),
+			"set":              tftypes.NewValue(testType.AttributeTypes["set"], []tftypes.Value{nested("set")}),
+			"map":              tftypes.NewValue(testType.AttributeTypes["map"], map[string]tftypes.Value{"key": nested("map")}),
+			"timeouts":         tftypes.NewValue(timeoutsType, timeoutValues),
+		})
+	}
+
+	testCases := map[string]struct {
+		values        map[string]string
+		size          int64
+		expectReplace bool
+		expectError   bool
+	}{
+		"unchanged": {
+			size: 100,
+		},
+		"update": {
+			size: 200,
+		},
+		"attribute": {
+			values:        map[string]string{"name": "b"},
+			size:          100,
+			expectReplace: true,
+		},
+		"single nested attribute": {
+			values:        map[string]string{"single": "b"},
+			size:          100,
+			expectReplace: true,
+		},
+		"list nested attribute": {
+			values:        map[string]string{"list": "b"},
+			size:          100,
+			expectReplace: true,
+		},
+		"set nested attribute": {
+			values:        map[string]string{"set": "b"},
+			size:          100,
+			expectReplace: true,
+		},
+		"map nested attribute": {
+			values:        map[string]string{"map": "b"},
+			size:          100,
+			expectReplace: true,
+		},
+		"modifier error": {
+			size:        50,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			planRaw := newRaw(testCase.values, testCase.size)
+
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: testSchema, Raw: planRaw},
+				Plan:   tfsdk.Plan{Schema: testSchema, Raw: planRaw},
+				State:  tfsdk.State{Schema: testSchema, Raw: newRaw(nil, 100)},
+			}
+
+			replace, diags := plannedReplacement(ctx, req)
+
+			if diags.HasError() != testCase.expectError {
+				t.Fatalf("expected error %t, got %v", testCase.expectError, diags)
+			}
+			if diags.WarningsCount() > 0 {
+				t.Errorf("unexpected warnings: %v", diags)
+			}
+
+			if replace != testCase.expectReplace {
+				t.Errorf("expected replace %t, got %t", testCase.expectReplace, replace)
+			}
+		})
+	}
+}
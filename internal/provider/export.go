@@ -0,0 +1,422 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExportAccount holds all resources of an account which are exported to HCL.
+// The JSON representation matches the list endpoints of the API and is used
+// for recorded fixtures.
+type ExportAccount struct {
+	Filesystems        []sagadata.Filesystem        `json:"filesystems"`
+	FloatingIPs        []sagadata.FloatingIP        `json:"floating_ips"`
+	Instances          []sagadata.Instance          `json:"instances"`
+	KubernetesClusters []sagadata.KubernetesCluster `json:"clusters"`
+	PrivateNetworks    []sagadata.PrivateNetwork    `json:"private_networks"`
+	SecurityGroups     []sagadata.SecurityGroup     `json:"security_groups"`
+	Snapshots          []sagadata.Snapshot          `json:"snapshots"`
+	SSHKeys            []sagadata.SSHKey            `json:"ssh_keys"`
+	Volumes            []sagadata.Volume            `json:"volumes"`
+}
+
+// FetchExportAccount lists all exported resources of the account.
+func FetchExportAccount(ctx context.Context, client *Client) (account *ExportAccount, diags diag.Diagnostics) {
+	account = &ExportAccount{}
+
+	var d diag.Diagnostics
+
+	account.Filesystems, d = listFilesystems(ctx, client)
+	diags.Append(d...)
+	account.FloatingIPs, d = listFloatingIPs(ctx, client)
+	diags.Append(d...)
+	account.Instances, d = listInstances(ctx, client)
+	diags.Append(d...)
+	account.KubernetesClusters, d = listKubernetesClusters(ctx, client)
+	diags.Append(d...)
+	account.PrivateNetworks, d = listPrivateNetworks(ctx, client)
+	diags.Append(d...)
+	account.SecurityGroups, d = listSecurityGroups(ctx, client)
+	diags.Append(d...)
+	account.Snapshots, d = listSnapshots(ctx, client)
+	diags.Append(d...)
+	account.SSHKeys, d = listSSHKeys(ctx, client)
+	diags.Append(d...)
+	account.Volumes, d = listVolumes(ctx, client)
+	diags.Append(d...)
+
+	return account, diags
+}
+
+var exportNameInvalidRegexp = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// exporter writes resources and their import blocks and keeps track of the
+// resource addresses so that references can be used instead of ids.
+type exporter struct {
+	body *hclwrite.Body
+
+	// addresses The resource type and name by resource id.
+	addresses map[string]hcl.Traversal
+
+	// names The used resource names by resource type.
+	names map[string]map[string]bool
+
+	// order The registered resource ids in the order of registration.
+	order []string
+
+	// current The id of the resource which is written.
+	current string
+
+	// edges The referenced resource ids by resource id.
+	edges map[string][]string
+
+	// literals The references which are written as ids, as they close a cycle.
+	literals map[[2]string]bool
+}
+
+// WriteExport writes the resources of the account as HCL together with import blocks.
+func WriteExport(w io.Writer, account *ExportAccount) error {
+	file := hclwrite.NewEmptyFile()
+
+	e := &exporter{
+		addresses: map[string]hcl.Traversal{},
+		names:     map[string]map[string]bool{},
+		edges:     map[string][]string{},
+		literals:  map[[2]string]bool{},
+	}
+
+	// Register all addresses first, so that references do not depend on the order
+	sortByName(account.SSHKeys, func(v sagadata.SSHKey) (string, string) { return v.Name, v.Id })
+	for _, v := range account.SSHKeys {
+		e.register("sagadata_ssh_key", v.Id, v.Name)
+	}
+	sortByName(account.SecurityGroups, func(v sagadata.SecurityGroup) (string, string) { return v.Name, v.Id })
+	for _, v := range account.SecurityGroups {
+		e.register("sagadata_security_group", v.Id, v.Name)
+	}
+	sortByName(account.FloatingIPs, func(v sagadata.FloatingIP) (string, string) { return v.Name, v.Id })
+	for _, v := range account.FloatingIPs {
+		e.register("sagadata_floating_ip", v.Id, v.Name)
+	}
+	sortByName(account.PrivateNetworks, func(v sagadata.PrivateNetwork) (string, string) { return v.Name, v.Id })
+	for _, v := range account.PrivateNetworks {
+		e.register("sagadata_private_network", v.Id, v.Name)
+	}
+	sortByName(account.Volumes, func(v sagadata.Volume) (string, string) { return v.Name, v.Id })
+	for _, v := range account.Volumes {
+		e.register("sagadata_volume", v.Id, v.Name)
+	}
+	sortByName(account.Filesystems, func(v sagadata.Filesystem) (string, string) { return v.Name, v.Id })
+	for _, v := range account.Filesystems {
+		e.register("sagadata_filesystem", v.Id, v.Name)
+	}
+	sortByName(account.KubernetesClusters, func(v sagadata.KubernetesCluster) (string, string) { return v.Name, v.Id })
+	for _, v := range account.KubernetesClusters {
+		e.register("sagadata_kubernetes_cluster", v.Id, v.Name)
+	}
+	sortByName(account.Instances, func(v sagadata.Instance) (string, string) { return v.Name, v.Id })
+	for _, v := range account.Instances {
+		e.register("sagadata_instance", v.Id, v.Name)
+	}
+	sortByName(account.Snapshots, func(v sagadata.Snapshot) (string, string) { return v.Name, v.Id })
+	for _, v := range account.Snapshots {
+		e.register("sagadata_snapshot", v.Id, v.Name)
+	}
+
+	// The first pass records the references, whose cycles would be rejected by Terraform
+	e.body = hclwrite.NewEmptyFile().Body()
+	e.write(account)
+	e.breakCycles()
+
+	e.body = file.Body()
+	e.write(account)
+
+	_, err := w.Write(hclwrite.Format(file.Bytes()))
+	return err
+}
+
+// write writes the resource and import blocks of the registered resources.
+func (e *exporter) write(account *ExportAccount) {
+	for _, v := range account.SSHKeys {
+		body := e.resource(v.Id)
+		body.SetAttributeValue("name", cty.StringVal(v.Name))
+		body.SetAttributeValue("public_key", cty.StringVal(v.Value))
+		e.importBlock(v.Id)
+	}
+
+	for _, v := range account.SecurityGroups {
+		body := e.resource(v.Id)
+		body.SetAttributeValue("name", cty.StringVal(v.Name))
+		setOptionalString(body, "description", v.Description)
+		body.SetAttributeValue("region", cty.StringVal(string(v.Region)))
+
+		rules := []cty.Value{}
+		for _, rule := range v.Rules {
+			attributes := map[string]cty.Value{
+				"direction": cty.StringVal(string(rule.Direction)),
+				"protocol":  cty.StringVal(string(rule.Protocol)),
+			}
+			if rule.PortRangeMin != nil {
+				attributes["port_range_min"] = cty.NumberIntVal(int64(*rule.PortRangeMin))
+			}
+			if rule.PortRangeMax != nil {
+				attributes["port_range_max"] = cty.NumberIntVal(int64(*rule.PortRangeMax))
+			}
+			rules = append(rules, cty.ObjectVal(attributes))
+		}
+		body.SetAttributeValue("rules", cty.TupleVal(rules))
+		e.importBlock(v.Id)
+	}
+
+	for _, v := range account.FloatingIPs {
+		body := e.resource(v.Id)
+		body.SetAttributeValue("name", cty.StringVal(v.Name))
+		setOptionalString(body, "description", v.Description)
+		body.SetAttributeValue("region", cty.StringVal(string(v.Region)))
+		body.SetAttributeValue("version", cty.StringVal(string(v.Version)))
+		e.importBlock(v.Id)
+	}
+
+	for _, v := range account.PrivateNetworks {
+		body := e.resource(v.Id)
+		body.SetAttributeValue("name", cty.StringVal(v.Name))
+		setOptionalString(body, "description", v.Description)
+		body.SetAttributeValue("region", cty.StringVal(string(v.Region)))
+		if v.CidrV4 != nil {
+			body.SetAttributeValue("cidr_v4", cty.StringVal(*v.CidrV4))
+		}
+		if v.CidrV6 != nil {
+			body.SetAttributeValue("cidr_v6", cty.StringVal(*v.CidrV6))
+		}
+		e.importBlock(v.Id)
+	}
+
+	for _, v := range account.Volumes {
+		body := e.resource(v.Id)
+		body.SetAttributeValue("name", cty.StringVal(v.Name))
+		setOptionalString(body, "description", v.Description)
+		body.SetAttributeValue("region", cty.StringVal(string(v.Region)))
+		body.SetAttributeValue("size", cty.NumberIntVal(int64(v.Size)))
+		body.SetAttributeValue("type", cty.StringVal(string(v.Type)))
+		if v.SourceSnapshotId != nil {
+			body.SetAttributeRaw("source_snapshot_id", e.reference(*v.SourceSnapshotId))
+		}
+		e.importBlock(v.Id)
+	}
+
+	for _, v := range account.Filesystems {
+		body := e.resource(v.Id)
+		body.SetAttributeValue("name", cty.StringVal(v.Name))
+		setOptionalString(body, "description", v.Description)
+		body.SetAttributeValue("region", cty.StringVal(string(v.Region)))
+		body.SetAttributeValue("size", cty.NumberIntVal(int64(v.Size)))
+		body.SetAttributeValue("type", cty.StringVal(string(v.Type)))
+		e.importBlock(v.Id)
+	}
+
+	for _, v := range account.KubernetesClusters {
+		body := e.resource(v.Id)
+		body.SetAttributeValue("name", cty.StringVal(v.Name))
+		if v.Network != nil {
+			body.SetAttributeRaw("network", e.reference(*v.Network))
+		}
+		e.importBlock(v.Id)
+	}
+
+	for _, v := range account.Instances {
+		body := e.resource(v.Id)
+		body.SetAttributeValue("name", cty.StringVal(v.Name))
+		body.SetAttributeValue("hostname", cty.StringVal(v.Hostname))
+		body.SetAttributeValue("region", cty.StringVal(string(v.Region)))
+		body.SetAttributeValue("type", cty.StringVal(string(v.Type)))
+		body.SetAttributeRaw("image", e.reference(v.Image.Id))
+		if v.DiskSize != nil {
+			body.SetAttributeValue("disk_size", cty.NumberIntVal(int64(*v.DiskSize)))
+		}
+		setOptionalString(body, "placement_option", v.PlacementOption)
+
+		var sshKeyIds, securityGroupIds, volumeIds, privateNetworkIds []string
+		for _, sshKey := range v.SshKeys {
+			sshKeyIds = append(sshKeyIds, sshKey.Id)
+		}
+		for _, securityGroup := range v.SecurityGroups {
+			securityGroupIds = append(securityGroupIds, securityGroup.Id)
+		}
+		for _, volume := range v.Volumes {
+			volumeIds = append(volumeIds, volume.Id)
+		}
+		for _, privateNetwork := range v.PrivateNetworks {
+			privateNetworkIds = append(privateNetworkIds, privateNetwork.Id)
+		}
+
+		if len(sshKeyIds) > 0 {
+			body.SetAttributeRaw("ssh_key_ids", e.references(sshKeyIds))
+		}
+		body.SetAttributeRaw("security_group_ids", e.references(securityGroupIds))
+		body.SetAttributeRaw("volume_ids", e.references(volumeIds))
+		if len(privateNetworkIds) > 0 {
+			body.SetAttributeRaw("private_network_ids", e.references(privateNetworkIds))
+		}
+
+		if v.FloatingIp != nil {
+			body.SetAttributeRaw("floating_ip_id", e.reference(v.FloatingIp.Id))
+		}
+		if v.ReservationId != nil {
+			body.SetAttributeValue("reservation_id", cty.StringVal(*v.ReservationId))
+		}
+		if v.K8sCluster != nil {
+			body.SetAttributeRaw("k8s_cluster_id", e.reference(*v.K8sCluster))
+		}
+		e.importBlock(v.Id)
+	}
+
+	for _, v := range account.Snapshots {
+		body := e.resource(v.Id)
+		body.SetAttributeValue("name", cty.StringVal(v.Name))
+		switch {
+		case v.SourceInstanceId != nil:
+			body.SetAttributeRaw("source_instance_id", e.reference(*v.SourceInstanceId))
+		case v.SourceVolumeId != nil:
+			body.SetAttributeRaw("source_volume_id", e.reference(*v.SourceVolumeId))
+		case v.SourceSnapshotId != nil:
+			body.SetAttributeValue("region", cty.StringVal(string(v.Region)))
+			body.SetAttributeRaw("source_snapshot_id", e.reference(*v.SourceSnapshotId))
+		}
+		e.importBlock(v.Id)
+	}
+}
+
+// breakCycles marks the references which close a cycle of references, found by
+// a depth-first search in the order of registration, to be written as ids.
+func (e *exporter) breakCycles() {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := map[string]int{}
+
+	var visit func(id string)
+	visit = func(id string) {
+		states[id] = visiting
+
+		for _, target := range e.edges[id] {
+			switch states[target] {
+			case unvisited:
+				visit(target)
+			case visiting:
+				e.literals[[2]string{id, target}] = true
+			}
+		}
+
+		states[id] = visited
+	}
+
+	for _, id := range e.order {
+		if states[id] == unvisited {
+			visit(id)
+		}
+	}
+}
+
+// register assigns a unique resource name within the resource type to the resource.
+func (e *exporter) register(typeName string, id string, name string) {
+	base := exportNameInvalidRegexp.ReplaceAllString(strings.ToLower(name), "_")
+	base = strings.Trim(base, "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "r_" + base
+	}
+
+	if e.names[typeName] == nil {
+		e.names[typeName] = map[string]bool{}
+	}
+
+	resourceName := base
+	for i := 2; e.names[typeName][resourceName]; i++ {
+		resourceName = fmt.Sprintf("%s_%d", base, i)
+	}
+	e.names[typeName][resourceName] = true
+
+	e.order = append(e.order, id)
+	e.addresses[id] = hcl.Traversal{
+		hcl.TraverseRoot{Name: typeName},
+		hcl.TraverseAttr{Name: resourceName},
+	}
+}
+
+// resource appends the resource block of the registered resource.
+func (e *exporter) resource(id string) *hclwrite.Body {
+	address := e.addresses[id]
+	e.current = id
+
+	block := e.body.AppendNewBlock("resource", []string{address.RootName(), address[1].(hcl.TraverseAttr).Name})
+	return block.Body()
+}
+
+// importBlock appends the import block of the registered resource.
+func (e *exporter) importBlock(id string) {
+	e.body.AppendNewline()
+
+	block := e.body.AppendNewBlock("import", nil)
+	block.Body().SetAttributeTraversal("to", e.addresses[id])
+	block.Body().SetAttributeValue("id", cty.StringVal(id))
+
+	e.body.AppendNewline()
+}
+
+// reference returns a reference to the id of an exported resource or the id
+// itself, when the resource is not exported or the reference closes a cycle.
+func (e *exporter) reference(id string) hclwrite.Tokens {
+	address, ok := e.addresses[id]
+	if !ok || e.literals[[2]string{e.current, id}] {
+		return hclwrite.TokensForValue(cty.StringVal(id))
+	}
+
+	if !slices.Contains(e.edges[e.current], id) {
+		e.edges[e.current] = append(e.edges[e.current], id)
+	}
+
+	return hclwrite.TokensForTraversal(append(address, hcl.TraverseAttr{Name: "id"}))
+}
+
+// references returns a list of references, see reference.
+func (e *exporter) references(ids []string) hclwrite.Tokens {
+	sort.Strings(ids)
+
+	var elements []hclwrite.Tokens
+	for _, id := range ids {
+		elements = append(elements, e.reference(id))
+	}
+
+	return hclwrite.TokensForTuple(elements)
+}
+
+func setOptionalString(body *hclwrite.Body, name string, value string) {
+	if value != "" {
+		body.SetAttributeValue(name, cty.StringVal(value))
+	}
+}
+
+// sortByName sorts the resources by name and id for a stable output.
+func sortByName[T any](items []T, key func(T) (string, string)) {
+	sort.SliceStable(items, func(i, j int) bool {
+		nameI, idI := key(items[i])
+		nameJ, idJ := key(items[j])
+		if nameI != nameJ {
+			return nameI < nameJ
+		}
+		return idI < idJ
+	})
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+)
+
+var updateExportFixtures = flag.Bool("update-export-fixtures", false, "update the expected export output")
+
+func TestWriteExport(t *testing.T) {
+	data, err := os.ReadFile("testdata/export/account.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var account ExportAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := WriteExport(&out, &account); err != nil {
+		t.Fatal(err)
+	}
+
+	if *updateExportFixtures {
+		if err := os.WriteFile("testdata/export/account.tf", out.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile("testdata/export/account.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(out.Bytes(), expected) {
+		t.Errorf("unexpected export output, run the test with -update-export-fixtures to update it:\n%s", out.String())
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 
 	"github.com/sagadata-public/sagadata-go"
+	"github.com/sagadata-public/terraform-provider-sagadata/internal/decreaseplanmodifier"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/defaultplanmodifier"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,9 +27,21 @@ import (
 var (
 	_ resource.Resource                = &FilesystemResource{}
 	_ resource.ResourceWithConfigure   = &FilesystemResource{}
+	_ resource.ResourceWithModifyPlan  = &FilesystemResource{}
 	_ resource.ResourceWithImportState = &FilesystemResource{}
+	_ resource.ResourceWithMoveState   = &FilesystemResource{}
+	_ resource.ResourceWithIdentity    = &FilesystemResource{}
 )
 
+// filesystemErrorFieldAttributes maps the fields of the filesystem requests to their attributes.
+var filesystemErrorFieldAttributes = map[string]string{
+	"description": "description",
+	"name":        "name",
+	"region":      "region",
+	"size":        "size",
+	"type":        "type",
+}
+
 func NewFilesystemResource() resource.Resource {
 	return &FilesystemResource{}
 }
@@ -36,6 +50,7 @@ func NewFilesystemResource() resource.Resource {
 type FilesystemResource struct {
 	ResourceWithClient
 	ResourceWithTimeout
+	ResourceWithIdIdentity
 }
 
 func (r *FilesystemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -75,9 +90,11 @@ func (r *FilesystemResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Required:            true,
 			}),
 			"region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "The identifier for the region this filesystem exists in.",
-				Required:            true,
+				MarkdownDescription: "The identifier for the region this filesystem exists in. Defaults to the `default_region` of the provider.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -87,6 +104,9 @@ func (r *FilesystemResource) Schema(ctx context.Context, req resource.SchemaRequ
 			"size": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
 				MarkdownDescription: "The storage size of this filesystem given in GiB.",
 				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					decreaseplanmodifier.Int64(path.Root("on_size_decrease")),
+				},
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
 				},
@@ -125,6 +145,18 @@ func (r *FilesystemResource) Schema(ctx context.Context, req resource.SchemaRequ
 			}),
 
 			// Internal
+			"on_size_decrease": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "What to do when `size` is decreased, as the filesystem cannot be shrunk in place. " +
+					"`error` rejects the plan, `replace` destroys and recreates the filesystem, losing its data.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					defaultplanmodifier.String(decreaseplanmodifier.BehaviorError),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(decreaseplanmodifier.AllBehaviors...),
+				},
+			}),
 			"retain_on_delete": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
 				MarkdownDescription: "Flag to retain the filesystem when the resource is deleted",
 				Optional:            true,
@@ -134,11 +166,18 @@ func (r *FilesystemResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			}),
 
+			"deletion_protection": deletionProtectionAttribute(ctx, "filesystem"),
+
 			"timeouts": timeouts.AttributesAll(ctx),
 		},
 	}
 }
 
+func (r *FilesystemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanDefaultRegion(ctx, r.client, req, resp)
+	modifyPlanDeletionProtection(ctx, "filesystem", req, resp)
+}
+
 func (r *FilesystemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data FilesystemResourceModel
 
@@ -148,7 +187,7 @@ func (r *FilesystemResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_filesystem").Create)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -171,7 +210,7 @@ func (r *FilesystemResource) Create(ctx context.Context, req resource.CreateRequ
 
 	filesystemResponse := response.JSON201
 	if filesystemResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("create filesystem", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("create filesystem", filesystemErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -186,6 +225,8 @@ func (r *FilesystemResource) Create(ctx context.Context, req resource.CreateRequ
 
 	tflog.Trace(ctx, "created a filesystem resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -194,48 +235,69 @@ func (r *FilesystemResource) Create(ctx context.Context, req resource.CreateRequ
 
 	filesystemId := filesystemResponse.Filesystem.Id
 
+	filesystem, diags := r.waitForCreated(ctx, filesystemId)
+	if diags.HasError() && isWaitTimeout(ctx) {
+		resp.Diagnostics.Append(markProvisioning(ctx, resp.Private, "filesystem")...)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, filesystem)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if filesystem.Status == sagadata.FilesystemStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling filesystem", ErrResourceInErrorState))
+	}
+}
+
+// filesystemProvisioned returns whether the filesystem finished provisioning.
+func filesystemProvisioned(filesystem *sagadata.Filesystem) bool {
+	return filesystem.Status == sagadata.FilesystemStatusCreated || filesystem.Status == sagadata.FilesystemStatusError
+}
+
+// waitForCreated polls the filesystem until it finished provisioning.
+func (r *FilesystemResource) waitForCreated(ctx context.Context, filesystemId string) (*sagadata.Filesystem, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	for {
 		err := r.client.PollingWait(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling filesystem", err))
-			return
+			diags.AddError("Polling Error", generateErrorMessage("polling filesystem", err))
+			return nil, diags
 		}
 
 		tflog.Trace(ctx, "polling a filesystem resource")
 
 		response, err := r.client.GetFilesystemWithResponse(ctx, filesystemId)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling filesystem", err))
-			return
+			diags.AddError("Client Error", generateErrorMessage("polling filesystem", err))
+			return nil, diags
 		}
 
 		filesystemResponse := response.JSON200
 		if filesystemResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling filesystem", ErrorResponse{
+			diags.Append(newClientErrorDiagnostic("polling filesystem", nil, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
 			}))
-			return
+			return nil, diags
 		}
 
-		status := filesystemResponse.Filesystem.Status
-		if status == sagadata.FilesystemStatusCreated || status == sagadata.FilesystemStatusError {
-			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &filesystemResponse.Filesystem)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			if status == sagadata.FilesystemStatusError {
-				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling filesystem", ErrResourceInErrorState))
-			}
-			return
+		if filesystemProvisioned(&filesystemResponse.Filesystem) {
+			return &filesystemResponse.Filesystem, diags
 		}
 	}
 }
@@ -249,7 +311,7 @@ func (r *FilesystemResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_filesystem").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -258,7 +320,7 @@ func (r *FilesystemResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	filesystemId := data.Id.ValueString()
 
-	response, err := r.client.GetFilesystemWithResponse(ctx, filesystemId)
+	response, err := r.client.GetFilesystemCachedWithResponse(ctx, filesystemId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read filesystem", err))
 		return
@@ -266,7 +328,7 @@ func (r *FilesystemResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	filesystemResponse := response.JSON200
 	if filesystemResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read filesystem", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read filesystem", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -274,19 +336,27 @@ func (r *FilesystemResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &filesystemResponse.Filesystem)...)
+	filesystem, diags := resumeProvisioning(ctx, resp.Private, "filesystem", filesystemId, &filesystemResponse.Filesystem, filesystemProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, filesystem)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Trace(ctx, "read a filesystem resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *FilesystemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data FilesystemResourceModel
+	var data, state FilesystemResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -294,13 +364,25 @@ func (r *FilesystemResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update, r.client.DefaultTimeouts("sagadata_filesystem").Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 	defer cancel()
 
+	_, diags := resumeProvisioning(ctx, resp.Private, "filesystem", data.Id.ValueString(), nil, filesystemProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	body := sagadata.UpdateFilesystemJSONRequestBody{}
 
 	body.Name = pointer(data.Name.ValueString())
@@ -308,6 +390,7 @@ func (r *FilesystemResource) Update(ctx context.Context, req resource.UpdateRequ
 	body.Size = pointer(int(data.Size.ValueInt64()))
 
 	filesystemId := data.Id.ValueString()
+	targetSize := *body.Size
 
 	response, err := r.client.UpdateFilesystemWithResponse(ctx, filesystemId, body)
 	if err != nil {
@@ -317,7 +400,7 @@ func (r *FilesystemResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	filesystemResponse := response.JSON200
 	if filesystemResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("update filesystem", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("update filesystem", filesystemErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -334,6 +417,62 @@ func (r *FilesystemResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if int64(targetSize) == state.Size.ValueInt64() {
+		return
+	}
+
+	tflog.Info(ctx, "resizing filesystem", map[string]interface{}{"id": filesystemId, "from": state.Size.ValueInt64(), "to": targetSize})
+
+	for {
+		err := r.client.PollingWait(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling filesystem resize", err))
+			return
+		}
+
+		response, err := r.client.GetFilesystemWithResponse(ctx, filesystemId)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling filesystem resize", err))
+			return
+		}
+
+		filesystemResponse := response.JSON200
+		if filesystemResponse == nil {
+			resp.Diagnostics.Append(newClientErrorDiagnostic("polling filesystem resize", nil, ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return
+		}
+
+		status := filesystemResponse.Filesystem.Status
+		size := filesystemResponse.Filesystem.Size
+
+		tflog.Info(ctx, "polling filesystem resize", map[string]interface{}{"id": filesystemId, "status": status, "size": size, "target_size": targetSize})
+
+		if (status == sagadata.FilesystemStatusCreated && size == targetSize) || status == sagadata.FilesystemStatusError {
+			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &filesystemResponse.Filesystem)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Save updated data into Terraform state
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			if status == sagadata.FilesystemStatusError {
+				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling filesystem resize", ErrResourceInErrorState))
+			}
+			return
+		}
+	}
 }
 
 func (r *FilesystemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -345,7 +484,7 @@ func (r *FilesystemResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete, r.client.DefaultTimeouts("sagadata_filesystem").Delete)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -354,6 +493,11 @@ func (r *FilesystemResource) Delete(ctx context.Context, req resource.DeleteRequ
 
 	filesystemId := data.Id.ValueString()
 
+	resp.Diagnostics.Append(checkDeletionProtection("filesystem", filesystemId, data.DeletionProtection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if data.RetainOnDelete.ValueBool() {
 		resp.Diagnostics.AddWarning(
 			"Filesystem is retained",
@@ -369,7 +513,7 @@ func (r *FilesystemResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("delete filesystem", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("delete filesystem", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -399,5 +543,11 @@ func (r *FilesystemResource) Delete(ctx context.Context, req resource.DeleteRequ
 }
 
 func (r *FilesystemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp, path.Root("id"), "filesystem", r.client, listFilesystemImportCandidates)
+}
+
+func (r *FilesystemResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		genesisCloudStateMover("_filesystem", nil),
+	}
 }
@@ -43,6 +43,13 @@ func TestAccFilesystemResource(t *testing.T) {
 					resource.TestCheckResourceAttr("sagadata_filesystem.test", "name", "two"),
 				),
 			},
+			// Resize testing
+			{
+				Config: providerConfig + testAccFilesystemResourceConfig("two", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sagadata_filesystem.test", "size", "2"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
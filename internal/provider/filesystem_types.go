@@ -42,9 +42,15 @@ type FilesystemResourceModel struct {
 
 	// Internal
 
+	// OnSizeDecrease What to do when the size of the filesystem is decreased.
+	OnSizeDecrease types.String `tfsdk:"on_size_decrease"`
+
 	// RetainOnDelete Flag to retain the filesystem when the resource is deleted. It has to be deleted manually.
 	RetainOnDelete types.Bool `tfsdk:"retain_on_delete"`
 
+	// DeletionProtection Flag to prevent the filesystem from being deleted.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
 	// Timeouts The resource timeouts
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
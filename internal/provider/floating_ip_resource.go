@@ -8,6 +8,7 @@ import (
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -21,9 +22,20 @@ import (
 var (
 	_ resource.Resource                = &FloatingIPResource{}
 	_ resource.ResourceWithConfigure   = &FloatingIPResource{}
+	_ resource.ResourceWithModifyPlan  = &FloatingIPResource{}
 	_ resource.ResourceWithImportState = &FloatingIPResource{}
+	_ resource.ResourceWithMoveState   = &FloatingIPResource{}
+	_ resource.ResourceWithIdentity    = &FloatingIPResource{}
 )
 
+// floatingIPErrorFieldAttributes maps the fields of the floating IP requests to their attributes.
+var floatingIPErrorFieldAttributes = map[string]string{
+	"description": "description",
+	"name":        "name",
+	"region":      "region",
+	"version":     "version",
+}
+
 func NewFloatingIPResource() resource.Resource {
 	return &FloatingIPResource{}
 }
@@ -32,6 +44,7 @@ func NewFloatingIPResource() resource.Resource {
 type FloatingIPResource struct {
 	ResourceWithClient
 	ResourceWithTimeout
+	ResourceWithIdIdentity
 }
 
 func (r *FloatingIPResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,9 +80,11 @@ func (r *FloatingIPResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Computed:            true,
 			}),
 			"region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "The region identifier.",
-				Required:            true,
+				MarkdownDescription: "The region identifier. Defaults to the `default_region` of the provider.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -123,6 +138,10 @@ func (r *FloatingIPResource) Schema(ctx context.Context, req resource.SchemaRequ
 	}
 }
 
+func (r *FloatingIPResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanDefaultRegion(ctx, r.client, req, resp)
+}
+
 func (r *FloatingIPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data FloatingIPResourceModel
 
@@ -132,7 +151,7 @@ func (r *FloatingIPResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_floating_ip").Create)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -153,7 +172,7 @@ func (r *FloatingIPResource) Create(ctx context.Context, req resource.CreateRequ
 
 	floatingIPResponse := response.JSON201
 	if floatingIPResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("create floating_ip", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("create floating_ip", floatingIPErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -168,6 +187,8 @@ func (r *FloatingIPResource) Create(ctx context.Context, req resource.CreateRequ
 
 	tflog.Trace(ctx, "created a floating_ip resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -176,48 +197,69 @@ func (r *FloatingIPResource) Create(ctx context.Context, req resource.CreateRequ
 
 	floatingIPId := floatingIPResponse.FloatingIp.Id
 
+	floatingIP, diags := r.waitForCreated(ctx, floatingIPId)
+	if diags.HasError() && isWaitTimeout(ctx) {
+		resp.Diagnostics.Append(markProvisioning(ctx, resp.Private, "floating IP")...)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, floatingIP)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if floatingIP.Status == sagadata.FloatingIpStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling floatingIP", ErrResourceInErrorState))
+	}
+}
+
+// floatingIPProvisioned returns whether the floating IP finished provisioning.
+func floatingIPProvisioned(floatingIP *sagadata.FloatingIP) bool {
+	return floatingIP.Status == sagadata.FloatingIpStatusCreated || floatingIP.Status == sagadata.FloatingIpStatusError
+}
+
+// waitForCreated polls the floating IP until it finished provisioning.
+func (r *FloatingIPResource) waitForCreated(ctx context.Context, floatingIPId string) (*sagadata.FloatingIP, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	for {
 		err := r.client.PollingWait(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling floatingIP", err))
-			return
+			diags.AddError("Polling Error", generateErrorMessage("polling floatingIP", err))
+			return nil, diags
 		}
 
 		tflog.Trace(ctx, "polling a floatingIP resource")
 
 		response, err := r.client.GetFloatingIPWithResponse(ctx, floatingIPId)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling floatingIP", err))
-			return
+			diags.AddError("Client Error", generateErrorMessage("polling floatingIP", err))
+			return nil, diags
 		}
 
 		floatingIPResponse := response.JSON200
 		if floatingIPResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling floatingIP", ErrorResponse{
+			diags.Append(newClientErrorDiagnostic("polling floatingIP", nil, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
 			}))
-			return
+			return nil, diags
 		}
 
-		status := floatingIPResponse.FloatingIp.Status
-		if status == sagadata.FloatingIpStatusCreated || status == sagadata.FloatingIpStatusError {
-			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &floatingIPResponse.FloatingIp)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			if status == sagadata.FloatingIpStatusError {
-				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling floatingIP", ErrResourceInErrorState))
-			}
-			return
+		if floatingIPProvisioned(&floatingIPResponse.FloatingIp) {
+			return &floatingIPResponse.FloatingIp, diags
 		}
 	}
 }
@@ -231,7 +273,7 @@ func (r *FloatingIPResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_floating_ip").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -240,7 +282,7 @@ func (r *FloatingIPResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	floatingIPId := data.Id.ValueString()
 
-	response, err := r.client.GetFloatingIPWithResponse(ctx, floatingIPId)
+	response, err := r.client.GetFloatingIPCachedWithResponse(ctx, floatingIPId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read floating_ip", err))
 		return
@@ -248,7 +290,7 @@ func (r *FloatingIPResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	floatingIPResponse := response.JSON200
 	if floatingIPResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read floating_ip", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read floating_ip", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -256,13 +298,21 @@ func (r *FloatingIPResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &floatingIPResponse.FloatingIp)...)
+	floatingIP, diags := resumeProvisioning(ctx, resp.Private, "floating IP", floatingIPId, &floatingIPResponse.FloatingIp, floatingIPProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, floatingIP)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Trace(ctx, "read a floating_ip resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -276,13 +326,19 @@ func (r *FloatingIPResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update, r.client.DefaultTimeouts("sagadata_floating_ip").Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 	defer cancel()
 
+	_, diags := resumeProvisioning(ctx, resp.Private, "floating IP", data.Id.ValueString(), nil, floatingIPProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	body := sagadata.UpdateFloatingIPJSONRequestBody{}
 
 	body.Name = pointer(data.Name.ValueString())
@@ -298,7 +354,7 @@ func (r *FloatingIPResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	floatingIPResponse := response.JSON200
 	if floatingIPResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("update floating_ip", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("update floating_ip", floatingIPErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -326,7 +382,7 @@ func (r *FloatingIPResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete, r.client.DefaultTimeouts("sagadata_floating_ip").Delete)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -342,7 +398,7 @@ func (r *FloatingIPResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("delete floating_ip", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("delete floating_ip", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -352,5 +408,11 @@ func (r *FloatingIPResource) Delete(ctx context.Context, req resource.DeleteRequ
 }
 
 func (r *FloatingIPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp, path.Root("id"), "floating ip", r.client, listFloatingIPImportCandidates)
+}
+
+func (r *FloatingIPResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		genesisCloudStateMover("_floating_ip", nil),
+	}
 }
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ResourceWithIdIdentity provides the identity schema of resources identified by their id.
+type ResourceWithIdIdentity struct{}
+
+func (r *ResourceWithIdIdentity) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The unique ID of the resource.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// setIdentity sets the identity attribute of a resource. The identity is nil
+// when Terraform does not support resource identities.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, attrPath path.Path, value types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+
+	return identity.SetAttribute(ctx, attrPath, value)
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSetIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	identitySchemaResp := &fwresource.IdentitySchemaResponse{}
+	(&ResourceWithIdIdentity{}).IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identitySchemaResp)
+
+	t.Run("nil identity", func(t *testing.T) {
+		diags := setIdentity(ctx, nil, path.Root("id"), types.StringValue("00000000-0000-0000-0000-000000000001"))
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		identity := &tfsdk.ResourceIdentity{
+			Schema: identitySchemaResp.IdentitySchema,
+			Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+		}
+
+		diags := setIdentity(ctx, identity, path.Root("id"), types.StringValue("00000000-0000-0000-0000-000000000001"))
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		var id types.String
+		diags.Append(identity.GetAttribute(ctx, path.Root("id"), &id)...)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		if id.ValueString() != "00000000-0000-0000-0000-000000000001" {
+			t.Errorf("expected id 00000000-0000-0000-0000-000000000001, got %s", id)
+		}
+	})
+
+	t.Run("unknown attribute", func(t *testing.T) {
+		identity := &tfsdk.ResourceIdentity{
+			Schema: identitySchemaResp.IdentitySchema,
+			Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+		}
+
+		diags := setIdentity(ctx, identity, path.Root("name"), types.StringValue("data"))
+		if !diags.HasError() {
+			t.Errorf("expected an error")
+		}
+	})
+}
@@ -50,7 +50,7 @@ func (d *ImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 						},
 					}),
 					"region": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
-						MarkdownDescription: "Filter by the region identifier.",
+						MarkdownDescription: "Filter by the region identifier. Defaults to the `default_region` of the provider.",
 						Optional:            true,
 						Validators: []validator.String{
 							stringvalidator.OneOf(sliceStringify(sagadata.AllRegions)...),
@@ -115,7 +115,7 @@ func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	ctx, cancel, diag := d.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := d.ContextWithTimeout(ctx, data.Timeouts.Read, d.client.DefaultTimeouts("sagadata_images").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -128,6 +128,8 @@ func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	if !data.Filter.Region.IsNull() && !data.Filter.Region.IsUnknown() {
 		filterRegion = pointer(sagadata.Region(data.Filter.Region.ValueString()))
+	} else if d.client.DefaultRegion != "" {
+		filterRegion = pointer(sagadata.Region(d.client.DefaultRegion))
 	}
 
 	for page := 1; ; page++ {
@@ -143,7 +145,7 @@ func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 		imagesResponse := response.JSON200
 		if imagesResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read images", ErrorResponse{
+			resp.Diagnostics.Append(newClientErrorDiagnostic("read images", nil, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+var importIdRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// importCandidate is a resource which can be matched by name during import.
+type importCandidate struct {
+	Id     string
+	Name   string
+	Region string
+}
+
+// importStateByName imports a resource by its identity, its id, its name or its
+// region and name separated by a slash, e.g. "NORD-NO-KRS-1/my-volume". Names
+// are resolved through the given list function and have to match exactly one
+// resource. The identity attribute has the same path as the state attribute.
+func importStateByName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, attrPath path.Path, kind string, client *Client, list func(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics)) {
+	if req.ID == "" || importIdRegexp.MatchString(req.ID) {
+		resource.ImportStatePassthroughWithIdentity(ctx, attrPath, attrPath, req, resp)
+		return
+	}
+
+	var region, name string
+
+	switch parts := strings.Split(req.ID, "/"); len(parts) {
+	case 1:
+		name = parts[0]
+	case 2:
+		region, name = parts[0], parts[1]
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import id of the form <id>, <name> or <region>/<name> for the %s, got: %q", kind, req.ID),
+		)
+		return
+	}
+
+	if name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import id of the form <id>, <name> or <region>/<name> for the %s, got: %q", kind, req.ID),
+		)
+		return
+	}
+
+	candidates, diags := list(ctx, client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var matches []importCandidate
+	for _, candidate := range candidates {
+		if candidate.Name != name {
+			continue
+		}
+
+		if region != "" && !strings.EqualFold(candidate.Region, region) {
+			continue
+		}
+
+		matches = append(matches, candidate)
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Resource Not Found",
+			fmt.Sprintf("No %s matches the import id %q.", kind, req.ID),
+		)
+	case 1:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, attrPath, matches[0].Id)...)
+	default:
+		var descriptions []string
+		for _, match := range matches {
+			descriptions = append(descriptions, fmt.Sprintf("%s (region %s)", match.Id, match.Region))
+		}
+
+		resp.Diagnostics.AddError(
+			"Ambiguous Import ID",
+			fmt.Sprintf("The import id %q matches %d %s resources: %s. Import by id or use <region>/<name> instead.", req.ID, len(matches), kind, strings.Join(descriptions, ", ")),
+		)
+	}
+}
+
+func listInstanceImportCandidates(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+	instances, diags := listInstances(ctx, client)
+
+	var candidates []importCandidate
+	for _, instance := range instances {
+		candidates = append(candidates, importCandidate{Id: instance.Id, Name: instance.Name, Region: string(instance.Region)})
+	}
+
+	return candidates, diags
+}
+
+func listVolumeImportCandidates(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+	volumes, diags := listVolumes(ctx, client)
+
+	var candidates []importCandidate
+	for _, volume := range volumes {
+		candidates = append(candidates, importCandidate{Id: volume.Id, Name: volume.Name, Region: string(volume.Region)})
+	}
+
+	return candidates, diags
+}
+
+func listFilesystemImportCandidates(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+	filesystems, diags := listFilesystems(ctx, client)
+
+	var candidates []importCandidate
+	for _, filesystem := range filesystems {
+		candidates = append(candidates, importCandidate{Id: filesystem.Id, Name: filesystem.Name, Region: string(filesystem.Region)})
+	}
+
+	return candidates, diags
+}
+
+func listSecurityGroupImportCandidates(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+	securityGroups, diags := listSecurityGroups(ctx, client)
+
+	var candidates []importCandidate
+	for _, securityGroup := range securityGroups {
+		candidates = append(candidates, importCandidate{Id: securityGroup.Id, Name: securityGroup.Name, Region: string(securityGroup.Region)})
+	}
+
+	return candidates, diags
+}
+
+func listSnapshotImportCandidates(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+	snapshots, diags := listSnapshots(ctx, client)
+
+	var candidates []importCandidate
+	for _, snapshot := range snapshots {
+		candidates = append(candidates, importCandidate{Id: snapshot.Id, Name: snapshot.Name, Region: string(snapshot.Region)})
+	}
+
+	return candidates, diags
+}
+
+func listSSHKeyImportCandidates(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+	sshKeys, diags := listSSHKeys(ctx, client)
+
+	var candidates []importCandidate
+	for _, sshKey := range sshKeys {
+		candidates = append(candidates, importCandidate{Id: sshKey.Id, Name: sshKey.Name})
+	}
+
+	return candidates, diags
+}
+
+func listFloatingIPImportCandidates(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+	floatingIPs, diags := listFloatingIPs(ctx, client)
+
+	var candidates []importCandidate
+	for _, floatingIP := range floatingIPs {
+		candidates = append(candidates, importCandidate{Id: floatingIP.Id, Name: floatingIP.Name, Region: string(floatingIP.Region)})
+	}
+
+	return candidates, diags
+}
+
+func listPrivateNetworkImportCandidates(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+	privateNetworks, diags := listPrivateNetworks(ctx, client)
+
+	var candidates []importCandidate
+	for _, privateNetwork := range privateNetworks {
+		candidates = append(candidates, importCandidate{Id: privateNetwork.Id, Name: privateNetwork.Name, Region: string(privateNetwork.Region)})
+	}
+
+	return candidates, diags
+}
+
+func listKubernetesClusterImportCandidates(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+	kubernetesClusters, diags := listKubernetesClusters(ctx, client)
+
+	var candidates []importCandidate
+	for _, kubernetesCluster := range kubernetesClusters {
+		candidates = append(candidates, importCandidate{Id: kubernetesCluster.Id, Name: kubernetesCluster.Name})
+	}
+
+	return candidates, diags
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImportStateByName(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewVolumeResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	list := func(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+		return []importCandidate{
+			{Id: "00000000-0000-0000-0000-000000000001", Name: "data", Region: "NORD-NO-KRS-1"},
+			{Id: "00000000-0000-0000-0000-000000000002", Name: "data", Region: "EUW-NL-AMS-1"},
+			{Id: "00000000-0000-0000-0000-000000000003", Name: "scratch", Region: "NORD-NO-KRS-1"},
+		}, nil
+	}
+
+	testCases := map[string]struct {
+		importId    string
+		expectedId  string
+		expectError bool
+	}{
+		"id": {
+			importId:   "18efeec8-94f0-4776-8ff2-5e9b49c74608",
+			expectedId: "18efeec8-94f0-4776-8ff2-5e9b49c74608",
+		},
+		"name": {
+			importId:   "scratch",
+			expectedId: "00000000-0000-0000-0000-000000000003",
+		},
+		"region and name": {
+			importId:   "EUW-NL-AMS-1/data",
+			expectedId: "00000000-0000-0000-0000-000000000002",
+		},
+		"ambiguous name": {
+			importId:    "data",
+			expectError: true,
+		},
+		"unknown name": {
+			importId:    "NORD-NO-KRS-1/unknown",
+			expectError: true,
+		},
+		"invalid": {
+			importId:    "NORD-NO-KRS-1/data/extra",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+
+			resp := &fwresource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaType, nil),
+				},
+			}
+
+			importStateByName(ctx, fwresource.ImportStateRequest{ID: testCase.importId}, resp, path.Root("id"), "volume", nil, list)
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if id.ValueString() != testCase.expectedId {
+				t.Errorf("expected id %q, got %q", testCase.expectedId, id.ValueString())
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/sagadata-public/terraform-provider-sagadata/internal/resourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource              = &InstanceActionResource{}
+	_ resource.ResourceWithConfigure = &InstanceActionResource{}
+)
+
+func NewInstanceActionResource() resource.Resource {
+	return &InstanceActionResource{}
+}
+
+// InstanceActionResource defines the resource implementation.
+type InstanceActionResource struct {
+	ResourceWithClient
+	ResourceWithTimeout
+}
+
+func (r *InstanceActionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_action"
+}
+
+func (r *InstanceActionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Performs an action on an instance when it is created and whenever its `triggers` change, " +
+			"e.g. to reset an instance after a driver update without replacing it. " +
+			"It waits for transient statuses, e.g. `stopping`, to settle before performing the action, and until the instance is in the stable status of the action. " +
+			"Destroying it does not change the instance.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The id of the instance to perform the action on.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			}),
+			"action": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The action to perform.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(sliceStringify(sagadata.AllInstanceActions)...),
+				},
+			}),
+			"triggers": resourceenhancer.Attribute(ctx, schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values which perform the action again when changed, e.g. the version of a driver.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			}),
+			"last_action": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The action which was last performed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			}),
+			"last_performed_at": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the action was last performed in RFC 3339.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			}),
+			"status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The instance status.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			}),
+
+			// Internal
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+// instanceActionTargetStatus returns the stable status of an instance after the action.
+func instanceActionTargetStatus(action sagadata.InstanceAction) sagadata.InstanceStatus {
+	switch action {
+	case sagadata.InstanceActionStop:
+		return sagadata.InstanceStatusStopped
+	case sagadata.InstanceActionShelve:
+		return sagadata.InstanceStatusShelved
+	default:
+		return sagadata.InstanceStatusActive
+	}
+}
+
+func (r *InstanceActionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data InstanceActionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_instance_action").Create)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	instanceId := data.InstanceId.ValueString()
+	action := sagadata.InstanceAction(data.Action.ValueString())
+
+	data.LastAction = types.StringValue(string(action))
+	data.LastPerformedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	data.Status = types.StringNull()
+
+	diags := transitionInstance(ctx, r.client, instanceId, instanceActionTargetStatus(action), instanceActionStep(action), populateInstanceAction(ctx, &data))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(diags...)
+}
+
+// populateInstanceAction returns a function which populates the data from each
+// polled instance.
+func populateInstanceAction(ctx context.Context, data *InstanceActionResourceModel) func(instance *sagadata.Instance) diag.Diagnostics {
+	return func(instance *sagadata.Instance) diag.Diagnostics {
+		return data.PopulateFromClientResponse(ctx, instance)
+	}
+}
+
+func (r *InstanceActionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data InstanceActionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_instance_action").Read)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	instanceId := data.InstanceId.ValueString()
+
+	response, err := r.client.GetInstanceCachedWithResponse(ctx, instanceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read instance action", err))
+		return
+	}
+
+	if response.StatusCode() == 404 {
+		tflog.Info(ctx, "instance of the instance action no longer exists", map[string]interface{}{"instance_id": instanceId})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	instanceResponse := response.JSON200
+	if instanceResponse == nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read instance action", nil, ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read instance action resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InstanceActionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data InstanceActionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the timeouts can be updated, all other changes perform the action again
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InstanceActionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data InstanceActionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the resource is not real so its a noop
+}
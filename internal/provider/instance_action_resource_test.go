@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/sagadata-public/sagadata-go"
+)
+
+func TestInstanceActionTargetStatus(t *testing.T) {
+	testCases := map[sagadata.InstanceAction]sagadata.InstanceStatus{
+		sagadata.InstanceActionStart:    sagadata.InstanceStatusActive,
+		sagadata.InstanceActionStop:     sagadata.InstanceStatusStopped,
+		sagadata.InstanceActionReset:    sagadata.InstanceStatusActive,
+		sagadata.InstanceActionShelve:   sagadata.InstanceStatusShelved,
+		sagadata.InstanceActionUnshelve: sagadata.InstanceStatusActive,
+	}
+
+	for action, status := range testCases {
+		if actual := instanceActionTargetStatus(action); actual != status {
+			t.Errorf("%s: expected %s, got %s", action, status, actual)
+		}
+	}
+}
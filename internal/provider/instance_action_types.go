@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type InstanceActionResourceModel struct {
+	// InstanceId The id of the instance to perform the action on.
+	InstanceId types.String `tfsdk:"instance_id"`
+
+	// Action The action to perform.
+	Action types.String `tfsdk:"action"`
+
+	// Triggers Arbitrary values which perform the action again when changed.
+	Triggers types.Map `tfsdk:"triggers"`
+
+	// LastAction The action which was last performed.
+	LastAction types.String `tfsdk:"last_action"`
+
+	// LastPerformedAt The timestamp when the action was last performed in RFC 3339.
+	LastPerformedAt types.String `tfsdk:"last_performed_at"`
+
+	// Status The instance status.
+	Status types.String `tfsdk:"status"`
+
+	// Internal
+
+	// Timeouts The resource timeouts
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (data *InstanceActionResourceModel) PopulateFromClientResponse(ctx context.Context, instance *sagadata.Instance) (diag diag.Diagnostics) {
+	data.InstanceId = types.StringValue(instance.Id)
+	data.Status = types.StringValue(string(instance.Status))
+
+	return
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// instanceDesiredStatuses are the statuses the desired_status of an instance
+// can be set to.
+var instanceDesiredStatuses = []sagadata.InstanceStatus{
+	sagadata.InstanceStatusActive,
+	sagadata.InstanceStatusStopped,
+}
+
+// modifyPlanDesiredStatus plans the observed status as unknown when it differs
+// from the desired status, so the instance is updated to enforce it, e.g. after
+// it was started outside of Terraform.
+func modifyPlanDesiredStatus(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var desiredStatus, status types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("desired_status"), &desiredStatus)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("status"), &status)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if desiredStatus.IsNull() || desiredStatus.IsUnknown() || desiredStatus.Equal(status) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringUnknown())...)
+}
+
+// enforceDesiredStatus transitions the instance to the desired status, if set,
+// and saves each polled instance into the state.
+func (r *InstanceResource) enforceDesiredStatus(ctx context.Context, data *InstanceResourceModel, state *tfsdk.State) diag.Diagnostics {
+	if data.DesiredStatus.IsNull() || data.DesiredStatus.IsUnknown() {
+		return nil
+	}
+
+	target := sagadata.InstanceStatus(data.DesiredStatus.ValueString())
+
+	return transitionInstanceStatus(ctx, r.client, data.Id.ValueString(), target, func(instance *sagadata.Instance) diag.Diagnostics {
+		diags := data.PopulateFromClientResponse(ctx, instance)
+		if diags.HasError() {
+			return diags
+		}
+
+		// Save data into Terraform state
+		diags.Append(state.Set(ctx, data)...)
+		return diags
+	})
+}
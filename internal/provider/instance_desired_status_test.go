@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestModifyPlanDesiredStatus(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewInstanceResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	// newState returns a state of an instance with the given statuses.
+	newState := func(t *testing.T, desiredStatus types.String, status types.String) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+
+		var diags diag.Diagnostics
+		diags.Append(state.SetAttribute(ctx, path.Root("name"), "trainer")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("desired_status"), desiredStatus)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("status"), status)...)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		return state
+	}
+
+	testCases := map[string]struct {
+		create         bool
+		desiredStatus  types.String
+		status         types.String
+		expectedStatus types.String
+	}{
+		"differs": {
+			desiredStatus:  types.StringValue("active"),
+			status:         types.StringValue("stopped"),
+			expectedStatus: types.StringUnknown(),
+		},
+		"matches": {
+			desiredStatus:  types.StringValue("stopped"),
+			status:         types.StringValue("stopped"),
+			expectedStatus: types.StringValue("stopped"),
+		},
+		"not set": {
+			desiredStatus:  types.StringNull(),
+			status:         types.StringValue("stopped"),
+			expectedStatus: types.StringValue("stopped"),
+		},
+		"unknown": {
+			desiredStatus:  types.StringUnknown(),
+			status:         types.StringValue("stopped"),
+			expectedStatus: types.StringValue("stopped"),
+		},
+		"create": {
+			create:         true,
+			desiredStatus:  types.StringValue("stopped"),
+			status:         types.StringUnknown(),
+			expectedStatus: types.StringUnknown(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := newState(t, testCase.desiredStatus, testCase.status)
+			if testCase.create {
+				state.Raw = tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+			}
+
+			plan := newState(t, testCase.desiredStatus, testCase.status)
+
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				State:  state,
+			}
+			resp := &fwresource.ModifyPlanResponse{
+				Plan: req.Plan,
+			}
+
+			modifyPlanDesiredStatus(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var status types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("status"), &status)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !status.Equal(testCase.expectedStatus) {
+				t.Errorf("expected status %s, got %s", testCase.expectedStatus, status)
+			}
+		})
+	}
+}
@@ -5,9 +5,12 @@ import (
 
 	"github.com/sagadata-public/sagadata-go"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/resourceenhancer"
+	"github.com/sagadata-public/terraform-provider-sagadata/internal/timedurationvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,10 +26,33 @@ import (
 var (
 	_ resource.Resource                     = &InstanceResource{}
 	_ resource.ResourceWithConfigure        = &InstanceResource{}
+	_ resource.ResourceWithModifyPlan       = &InstanceResource{}
 	_ resource.ResourceWithImportState      = &InstanceResource{}
 	_ resource.ResourceWithConfigValidators = &InstanceResource{}
+	_ resource.ResourceWithMoveState        = &InstanceResource{}
+	_ resource.ResourceWithIdentity         = &InstanceResource{}
 )
 
+// instanceErrorFieldAttributes maps the fields of the instance requests to their attributes.
+var instanceErrorFieldAttributes = map[string]string{
+	"disk_size":        "disk_size",
+	"floating_ip":      "floating_ip_id",
+	"hostname":         "hostname",
+	"image":            "image",
+	"k8s_cluster":      "k8s_cluster_id",
+	"metadata":         "metadata",
+	"name":             "name",
+	"password":         "password",
+	"placement_option": "placement_option",
+	"private_networks": "private_network_ids",
+	"region":           "region",
+	"reservation_id":   "reservation_id",
+	"security_groups":  "security_group_ids",
+	"ssh_keys":         "ssh_key_ids",
+	"type":             "type",
+	"volumes":          "volume_ids",
+}
+
 func NewInstanceResource() resource.Resource {
 	return &InstanceResource{}
 }
@@ -35,6 +61,7 @@ func NewInstanceResource() resource.Resource {
 type InstanceResource struct {
 	ResourceWithClient
 	ResourceWithTimeout
+	ResourceWithIdIdentity
 }
 
 func (r *InstanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,6 +74,28 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 		MarkdownDescription: "Instance resource",
 
 		Attributes: map[string]schema.Attribute{
+			"capacity_fallback": schema.SingleNestedAttribute{
+				MarkdownDescription: "The alternatives to try in order when there is no capacity for the instance. " +
+					"The `type` is tried with the `placement_option` and each fallback placement option first, followed by each fallback type. " +
+					"The values the instance was created with are recorded in `selected_type` and `selected_placement_option`.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"placement_options": resourceenhancer.Attribute(ctx, schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "The placement options to try after the `placement_option`.",
+						Optional:            true,
+					}),
+					"types": resourceenhancer.Attribute(ctx, schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "The instance types to try after the `type`.",
+						Optional:            true,
+					}),
+					"retry": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
+						MarkdownDescription: "Whether to try all alternatives again with an exponential backoff until the create timeout expires when none has capacity.",
+						Optional:            true,
+					}),
+				},
+			},
 			"created_at": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The timestamp when this image was created in RFC 3339.",
 				Computed:            true,
@@ -63,6 +112,14 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.UseStateForUnknown(), // immutable
 				},
 			}),
+			"desired_status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The status to keep the instance in, which is enforced on create and update, e.g. to stop instances overnight. " +
+					"The observed status is the `status` attribute. Do not combine it with a `sagadata_instance_status` resource for the same instance.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sliceStringify(instanceDesiredStatuses)...),
+				},
+			}),
 			"dns_name": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The dns name of the instance.",
 				Computed:            true,
@@ -80,7 +137,9 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 			"image": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The source image id, image slug or snapshot id of the instance. " +
 					"The image version can also specified together with the image slug in this format `<image-slug>:<version>`. " +
-					"Learn more about images [here](https://developers.sagadata.no/images).",
+					"Learn more about images [here](https://developers.sagadata.no/images). " +
+					"Changing the image replaces the instance, which releases its public IP, DNS name and reservation, " +
+					"as the API does not offer a rebuild action to reinstall it in place yet.",
 				Required: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -162,9 +221,11 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			}),
 			"region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "The region identifier.",
-				Required:            true,
+				MarkdownDescription: "The region identifier. Defaults to the `default_region` of the provider.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -190,11 +251,25 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 					setplanmodifier.RequiresReplace(),
 				},
 			}),
+			"selected_placement_option": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The placement option the instance was created in, which differs from the `placement_option` when a `capacity_fallback` placement option was used.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // immutable
+				},
+			}),
+			"selected_type": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The instance type the instance was created with, which differs from the `type` when a `capacity_fallback` type was used.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // immutable
+				},
+			}),
 			"status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The instance status.",
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(), // immutable
+					stringplanmodifier.UseStateForUnknown(), // unless the desired_status differs
 				},
 			}),
 			"type": resourceenhancer.Attribute(ctx, schema.StringAttribute{
@@ -208,6 +283,35 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "The timestamp when this image was last updated in RFC 3339.",
 				Computed:            true,
 			}),
+			"wait_for": schema.SingleNestedAttribute{
+				MarkdownDescription: "Wait until the instance is usable after it is created, not only active, e.g. until SSH accepts connections. " +
+					"Waiting for the completion of the startup script is not supported, as the API does not expose it. " +
+					"It is skipped when the instance is not active, e.g. with a `desired_status` of `stopped`.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"tcp_port": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+						MarkdownDescription: "The TCP port which has to accept connections, e.g. `22` for SSH.",
+						Required:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+					}),
+					"address": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: "The IP address to connect to, `public_ip` or `private_ip`. Defaults to `public_ip`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("public_ip", "private_ip"),
+						},
+					}),
+					"timeout": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: "How long to wait for the instance to be ready, independent of the create timeout. Defaults to `10m`.",
+						Optional:            true,
+						Validators: []validator.String{
+							timedurationvalidator.Positive(),
+						},
+					}),
+				},
+			},
 			"volume_ids": resourceenhancer.Attribute(ctx, schema.SetAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "The volumes of the instance.",
@@ -239,6 +343,8 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			}),
 
+			"deletion_protection": deletionProtectionAttribute(ctx, "instance"),
+
 			// Internal
 			"timeouts": timeouts.AttributesAll(ctx),
 		},
@@ -254,6 +360,12 @@ func (r *InstanceResource) ConfigValidators(ctx context.Context) []resource.Conf
 	}
 }
 
+func (r *InstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanDefaultRegion(ctx, r.client, req, resp)
+	modifyPlanDesiredStatus(ctx, req, resp)
+	modifyPlanDeletionProtection(ctx, "instance", req, resp)
+}
+
 func (r *InstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data InstanceResourceModel
 
@@ -263,7 +375,10 @@ func (r *InstanceResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	// wait_for has its own timeout
+	requestCtx := ctx
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_instance").Create)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -343,22 +458,31 @@ func (r *InstanceResource) Create(ctx context.Context, req resource.CreateReques
 		body.K8sCluster = data.K8sClusterId.ValueStringPointer()
 	}
 
-	response, err := r.client.CreateInstanceWithResponse(ctx, body)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", generateErrorMessage("create instance", err))
-		return
+	var fallbackTypes, fallbackPlacementOptions []string
+	var retry bool
+
+	if data.CapacityFallback != nil {
+		if !data.CapacityFallback.Types.IsNull() && !data.CapacityFallback.Types.IsUnknown() {
+			data.CapacityFallback.Types.ElementsAs(ctx, &fallbackTypes, false)
+		}
+
+		if !data.CapacityFallback.PlacementOptions.IsNull() && !data.CapacityFallback.PlacementOptions.IsUnknown() {
+			data.CapacityFallback.PlacementOptions.ElementsAs(ctx, &fallbackPlacementOptions, false)
+		}
+
+		retry = data.CapacityFallback.Retry.ValueBool()
 	}
 
-	instanceResponse := response.JSON201
-	if instanceResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("create instance", ErrorResponse{
-			Body:         response.Body,
-			HTTPResponse: response.HTTPResponse,
-			Error:        response.JSONDefault,
-		}))
+	candidates := capacityFallbackCandidates(string(body.Type), body.PlacementOption, fallbackTypes, fallbackPlacementOptions)
+
+	response, diags := r.createInstanceWithCapacityFallback(ctx, body, candidates, retry)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	instanceResponse := response.JSON201
+
 	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -366,6 +490,8 @@ func (r *InstanceResource) Create(ctx context.Context, req resource.CreateReques
 
 	tflog.Trace(ctx, "created a instance resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -374,46 +500,82 @@ func (r *InstanceResource) Create(ctx context.Context, req resource.CreateReques
 
 	instanceId := instanceResponse.Instance.Id
 
+	instance, diags := r.waitForCreated(ctx, instanceId)
+	if diags.HasError() && isWaitTimeout(ctx) {
+		resp.Diagnostics.Append(markProvisioning(ctx, resp.Private, "instance")...)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, instance)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if instance.Status == sagadata.InstanceStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling instance", ErrResourceInErrorState))
+		return
+	}
+
+	resp.Diagnostics.Append(r.enforceDesiredStatus(ctx, &data, &resp.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A stopped instance does not accept connections
+	if data.Status.ValueString() != string(sagadata.InstanceStatusActive) {
+		return
+	}
+
+	resp.Diagnostics.Append(r.waitForInstance(requestCtx, &data)...)
+}
+
+// instanceProvisioned returns whether the instance finished provisioning.
+func instanceProvisioned(instance *sagadata.Instance) bool {
+	return instance.Status == sagadata.InstanceStatusActive || instance.Status == sagadata.InstanceStatusError
+}
+
+// waitForCreated polls the instance until it finished provisioning.
+func (r *InstanceResource) waitForCreated(ctx context.Context, instanceId string) (*sagadata.Instance, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	for {
 		err := r.client.PollingWait(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling instance", err))
-			return
+			diags.AddError("Polling Error", generateErrorMessage("polling instance", err))
+			return nil, diags
 		}
 
+		tflog.Trace(ctx, "polling a instance resource")
+
 		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling instance", err))
-			return
+			diags.AddError("Client Error", generateErrorMessage("polling instance", err))
+			return nil, diags
 		}
 
 		instanceResponse := response.JSON200
 		if instanceResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling instance", ErrorResponse{
+			diags.Append(newClientErrorDiagnostic("polling instance", nil, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
 			}))
-			return
+			return nil, diags
 		}
 
-		status := instanceResponse.Instance.Status
-		if status == sagadata.InstanceStatusActive || status == sagadata.InstanceStatusError {
-			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			if status == sagadata.InstanceStatusError {
-				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling instance", ErrResourceInErrorState))
-			}
-			return
+		if instanceProvisioned(&instanceResponse.Instance) {
+			return &instanceResponse.Instance, diags
 		}
 	}
 }
@@ -427,7 +589,7 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_instance").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -436,7 +598,7 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	instanceId := data.Id.ValueString()
 
-	response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
+	response, err := r.client.GetInstanceCachedWithResponse(ctx, instanceId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read instance", err))
 		return
@@ -444,7 +606,7 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	instanceResponse := response.JSON200
 	if instanceResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read instance", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read instance", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -452,13 +614,21 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
+	instance, diags := resumeProvisioning(ctx, resp.Private, "instance", instanceId, &instanceResponse.Instance, instanceProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, instance)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Trace(ctx, "read a instance resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -472,13 +642,19 @@ func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update, r.client.DefaultTimeouts("sagadata_instance").Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 	defer cancel()
 
+	_, diags := resumeProvisioning(ctx, resp.Private, "instance", data.Id.ValueString(), nil, instanceProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	body := sagadata.UpdateInstanceJSONRequestBody{}
 
 	body.Name = pointer(data.Name.ValueString())
@@ -526,7 +702,7 @@ func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateReques
 
 	instanceResponse := response.JSON200
 	if instanceResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("update instance", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("update instance", instanceErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -543,6 +719,11 @@ func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateReques
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.enforceDesiredStatus(ctx, &data, &resp.State)...)
 }
 
 func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -554,7 +735,7 @@ func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete, r.client.DefaultTimeouts("sagadata_instance").Delete)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -563,6 +744,11 @@ func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteReques
 
 	instanceId := data.Id.ValueString()
 
+	resp.Diagnostics.Append(checkDeletionProtection("instance", instanceId, data.DeletionProtection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	response, err := r.client.DeleteInstanceWithResponse(ctx, instanceId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("delete instance", err))
@@ -570,7 +756,7 @@ func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("delete instance", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("delete instance", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -600,5 +786,11 @@ func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r *InstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp, path.Root("id"), "instance", r.client, listInstanceImportCandidates)
+}
+
+func (r *InstanceResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		genesisCloudStateMover("_instance", nil),
+	}
 }
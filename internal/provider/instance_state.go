@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// instanceStatusTargets are the statuses an instance can be transitioned to.
+var instanceStatusTargets = []sagadata.InstanceStatus{
+	sagadata.InstanceStatusActive,
+	sagadata.InstanceStatusStopped,
+	sagadata.InstanceStatusShelved,
+}
+
+// instancePendingPolls The number of polls after an action which may still
+// show the status the action was performed in, as the API may not have started
+// the action yet. Actions which end in the same status, e.g. a reset whose
+// restarting status was never polled, are done after them.
+const instancePendingPolls = 5
+
+// instanceStep is the next step of the transition of an instance to a target status.
+type instanceStep int
+
+const (
+	// instanceStepDone The instance is in the target status.
+	instanceStepDone instanceStep = iota
+
+	// instanceStepWait The instance is in a transient status which settles by itself.
+	instanceStepWait
+
+	// instanceStepAction The action has to be performed.
+	instanceStepAction
+
+	// instanceStepInvalid The instance cannot be transitioned to the target status.
+	instanceStepInvalid
+)
+
+// instanceStatusTransient returns whether the instance leaves the status by itself.
+func instanceStatusTransient(status sagadata.InstanceStatus) bool {
+	switch status {
+	case sagadata.InstanceStatusEnqueued,
+		sagadata.InstanceStatusCreating,
+		sagadata.InstanceStatusStarting,
+		sagadata.InstanceStatusStopping,
+		sagadata.InstanceStatusRestarting,
+		sagadata.InstanceStatusShelving,
+		sagadata.InstanceStatusUnshelving,
+		sagadata.InstanceStatusUpdating:
+		return true
+	default:
+		return false
+	}
+}
+
+// instanceStatusStep returns the next step to transition an instance from the
+// current to the target status, and the action to perform for an action step.
+// Transitions may take several actions, e.g. a shelved instance is unshelved
+// before it is stopped.
+func instanceStatusStep(current sagadata.InstanceStatus, target sagadata.InstanceStatus) (instanceStep, sagadata.InstanceAction) {
+	if current == target {
+		return instanceStepDone, ""
+	}
+
+	if instanceStatusTransient(current) {
+		return instanceStepWait, ""
+	}
+
+	switch target {
+	case sagadata.InstanceStatusActive:
+		switch current {
+		case sagadata.InstanceStatusStopped:
+			return instanceStepAction, sagadata.InstanceActionStart
+		case sagadata.InstanceStatusShelved:
+			return instanceStepAction, sagadata.InstanceActionUnshelve
+		}
+	case sagadata.InstanceStatusStopped:
+		switch current {
+		case sagadata.InstanceStatusActive, sagadata.InstanceStatusError:
+			return instanceStepAction, sagadata.InstanceActionStop
+		case sagadata.InstanceStatusShelved:
+			return instanceStepAction, sagadata.InstanceActionUnshelve
+		}
+	case sagadata.InstanceStatusShelved:
+		switch current {
+		case sagadata.InstanceStatusActive, sagadata.InstanceStatusStopped:
+			return instanceStepAction, sagadata.InstanceActionShelve
+		}
+	}
+
+	return instanceStepInvalid, ""
+}
+
+// instanceActionStep returns the step function to perform the action once. It
+// waits for transient statuses to settle before and after the action, and is
+// done once the instance is in the stable status after the action.
+func instanceActionStep(action sagadata.InstanceAction) func(current sagadata.InstanceStatus, performed bool) (instanceStep, sagadata.InstanceAction) {
+	target := instanceActionTargetStatus(action)
+
+	return func(current sagadata.InstanceStatus, performed bool) (instanceStep, sagadata.InstanceAction) {
+		if instanceStatusTransient(current) {
+			return instanceStepWait, ""
+		}
+
+		if !performed {
+			return instanceStepAction, action
+		}
+
+		if current == target {
+			return instanceStepDone, ""
+		}
+
+		return instanceStepInvalid, ""
+	}
+}
+
+// instanceClient is the part of the client used to transition instances.
+type instanceClient interface {
+	PollingWait(ctx context.Context) error
+	getInstance(ctx context.Context, instanceId string) (*sagadata.Instance, diag.Diagnostics)
+	performInstanceAction(ctx context.Context, instanceId string, action sagadata.InstanceAction) diag.Diagnostics
+}
+
+// getInstance reads the instance without the read cache.
+func (c *Client) getInstance(ctx context.Context, instanceId string) (*sagadata.Instance, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	response, err := c.GetInstanceWithResponse(ctx, instanceId)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage("polling instance status", err))
+		return nil, diags
+	}
+
+	instanceResponse := response.JSON200
+	if instanceResponse == nil {
+		diags.Append(newClientErrorDiagnostic("polling instance status", nil, ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return nil, diags
+	}
+
+	return &instanceResponse.Instance, diags
+}
+
+// performInstanceAction performs the action on the instance.
+func (c *Client) performInstanceAction(ctx context.Context, instanceId string, action sagadata.InstanceAction) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	body := sagadata.PerformInstanceActionJSONRequestBody{}
+	body.Action = action
+
+	response, err := c.PerformInstanceActionWithResponse(ctx, instanceId, body)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage("perform instance action", err))
+		return diags
+	}
+
+	if response.StatusCode() != 204 {
+		diags.Append(newClientErrorDiagnostic("perform instance action", nil, ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return diags
+	}
+
+	tflog.Trace(ctx, "performed instance action", map[string]interface{}{"action": action})
+
+	return diags
+}
+
+// transitionInstanceStatus transitions the instance to the target status. It
+// waits for transient statuses to settle and performs the actions of the
+// transition until the instance is in the target status. Every polled instance
+// is passed to observe, e.g. to save it into the state.
+func transitionInstanceStatus(ctx context.Context, client instanceClient, instanceId string, target sagadata.InstanceStatus, observe func(instance *sagadata.Instance) diag.Diagnostics) diag.Diagnostics {
+	return transitionInstance(ctx, client, instanceId, target, func(current sagadata.InstanceStatus, performed bool) (instanceStep, sagadata.InstanceAction) {
+		return instanceStatusStep(current, target)
+	}, observe)
+}
+
+// transitionInstance polls the instance and takes the steps returned by next
+// until it is done. After an action the instance is only passed to next again
+// once it left the status in which the action was performed, or after the
+// instancePendingPolls, as the API may not have started the action yet.
+func transitionInstance(ctx context.Context, client instanceClient, instanceId string, target sagadata.InstanceStatus, next func(current sagadata.InstanceStatus, performed bool) (instanceStep, sagadata.InstanceAction), observe func(instance *sagadata.Instance) diag.Diagnostics) (diags diag.Diagnostics) {
+	// pendingStatus The status in which the last action was performed, until the instance leaves it
+	var pendingStatus sagadata.InstanceStatus
+
+	// pendingPolls The number of polls which still showed the pendingStatus
+	var pendingPolls int
+
+	performed := false
+
+	for first := true; ; first = false {
+		if !first {
+			err := client.PollingWait(ctx)
+			if err != nil {
+				diags.AddError("Polling Error", generateErrorMessage("polling instance status", err))
+				return
+			}
+
+			tflog.Trace(ctx, "polling instance status")
+		}
+
+		instance, getDiags := client.getInstance(ctx, instanceId)
+		diags.Append(getDiags...)
+		if diags.HasError() {
+			return
+		}
+
+		diags.Append(observe(instance)...)
+		if diags.HasError() {
+			return
+		}
+
+		status := instance.Status
+
+		// The last action has not been started yet
+		if status == pendingStatus && pendingPolls < instancePendingPolls {
+			pendingPolls++
+			continue
+		}
+		pendingStatus = ""
+
+		step, action := next(status, performed)
+
+		switch step {
+		case instanceStepDone:
+			return
+		case instanceStepWait:
+			tflog.Info(ctx, "waiting for the transient instance status to settle", map[string]interface{}{"id": instanceId, "status": status})
+			continue
+		case instanceStepInvalid:
+			diags.AddError("Cannot transition instance status",
+				fmt.Sprintf("The instance resource with id %q cannot be transitioned from %q status to %q status.",
+					instanceId, status, target))
+			return
+		}
+
+		diags.Append(client.performInstanceAction(ctx, instanceId, action)...)
+		if diags.HasError() {
+			return
+		}
+
+		performed = true
+		pendingStatus = status
+		pendingPolls = 0
+	}
+}
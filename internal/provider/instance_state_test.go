@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestInstanceStatusStep(t *testing.T) {
+	testCases := []struct {
+		current sagadata.InstanceStatus
+		target  sagadata.InstanceStatus
+		step    instanceStep
+		action  sagadata.InstanceAction
+	}{
+		{sagadata.InstanceStatusActive, sagadata.InstanceStatusActive, instanceStepDone, ""},
+		{sagadata.InstanceStatusStopped, sagadata.InstanceStatusActive, instanceStepAction, sagadata.InstanceActionStart},
+		{sagadata.InstanceStatusShelved, sagadata.InstanceStatusActive, instanceStepAction, sagadata.InstanceActionUnshelve},
+		{sagadata.InstanceStatusStopping, sagadata.InstanceStatusActive, instanceStepWait, ""},
+		{sagadata.InstanceStatusError, sagadata.InstanceStatusActive, instanceStepInvalid, ""},
+		{sagadata.InstanceStatusDeleting, sagadata.InstanceStatusActive, instanceStepInvalid, ""},
+
+		{sagadata.InstanceStatusActive, sagadata.InstanceStatusStopped, instanceStepAction, sagadata.InstanceActionStop},
+		{sagadata.InstanceStatusError, sagadata.InstanceStatusStopped, instanceStepAction, sagadata.InstanceActionStop},
+		{sagadata.InstanceStatusShelved, sagadata.InstanceStatusStopped, instanceStepAction, sagadata.InstanceActionUnshelve},
+		{sagadata.InstanceStatusStarting, sagadata.InstanceStatusStopped, instanceStepWait, ""},
+		{sagadata.InstanceStatusStopped, sagadata.InstanceStatusStopped, instanceStepDone, ""},
+
+		{sagadata.InstanceStatusActive, sagadata.InstanceStatusShelved, instanceStepAction, sagadata.InstanceActionShelve},
+		{sagadata.InstanceStatusStopped, sagadata.InstanceStatusShelved, instanceStepAction, sagadata.InstanceActionShelve},
+		{sagadata.InstanceStatusUnshelving, sagadata.InstanceStatusShelved, instanceStepWait, ""},
+		{sagadata.InstanceStatusError, sagadata.InstanceStatusShelved, instanceStepInvalid, ""},
+	}
+
+	for _, testCase := range testCases {
+		step, action := instanceStatusStep(testCase.current, testCase.target)
+		if step != testCase.step || action != testCase.action {
+			t.Errorf("%s -> %s: expected %d %q, got %d %q", testCase.current, testCase.target, testCase.step, testCase.action, step, action)
+		}
+	}
+}
+
+// fakeInstanceClient is an instance client whose instance goes through the
+// statuses of each action, starting them only after some polls.
+type fakeInstanceClient struct {
+	// statuses The statuses returned by the next polls, the last one repeats
+	statuses []sagadata.InstanceStatus
+
+	// transitions The statuses the instance goes through after each action
+	transitions map[sagadata.InstanceAction][]sagadata.InstanceStatus
+
+	// lag The number of polls after an action which still return the previous status
+	lag int
+
+	// performed The actions performed, with the status they were performed in
+	performed []string
+}
+
+func (c *fakeInstanceClient) PollingWait(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (c *fakeInstanceClient) getInstance(ctx context.Context, instanceId string) (*sagadata.Instance, diag.Diagnostics) {
+	status := c.statuses[0]
+	if len(c.statuses) > 1 {
+		c.statuses = c.statuses[1:]
+	}
+
+	return &sagadata.Instance{Id: instanceId, Status: status}, nil
+}
+
+func (c *fakeInstanceClient) performInstanceAction(ctx context.Context, instanceId string, action sagadata.InstanceAction) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	current := c.statuses[0]
+	c.performed = append(c.performed, fmt.Sprintf("%s in %s", action, current))
+
+	transition, ok := c.transitions[action]
+	if !ok {
+		diags.AddError("Invalid Action", string(action))
+		return diags
+	}
+
+	statuses := []sagadata.InstanceStatus{}
+	for range c.lag {
+		statuses = append(statuses, current)
+	}
+	c.statuses = append(statuses, transition...)
+
+	return diags
+}
+
+var fakeInstanceTransitions = map[sagadata.InstanceAction][]sagadata.InstanceStatus{
+	sagadata.InstanceActionStart:    {sagadata.InstanceStatusStarting, sagadata.InstanceStatusActive},
+	sagadata.InstanceActionStop:     {sagadata.InstanceStatusStopping, sagadata.InstanceStatusStopped},
+	sagadata.InstanceActionReset:    {sagadata.InstanceStatusRestarting, sagadata.InstanceStatusActive},
+	sagadata.InstanceActionShelve:   {sagadata.InstanceStatusShelving, sagadata.InstanceStatusShelved},
+	sagadata.InstanceActionUnshelve: {sagadata.InstanceStatusUnshelving, sagadata.InstanceStatusActive},
+}
+
+func TestTransitionInstance(t *testing.T) {
+	testCases := map[string]struct {
+		statuses          []sagadata.InstanceStatus
+		transitions       map[sagadata.InstanceAction][]sagadata.InstanceStatus
+		action            sagadata.InstanceAction
+		target            sagadata.InstanceStatus
+		expectedPerformed []string
+		expectedStatuses  []sagadata.InstanceStatus
+		expectError       bool
+	}{
+		"reset": {
+			statuses:          []sagadata.InstanceStatus{sagadata.InstanceStatusActive},
+			action:            sagadata.InstanceActionReset,
+			expectedPerformed: []string{"reset in active"},
+			expectedStatuses:  []sagadata.InstanceStatus{"active", "active", "active", "restarting", "active"},
+		},
+		"reset without restarting": {
+			statuses: []sagadata.InstanceStatus{sagadata.InstanceStatusActive},
+			transitions: map[sagadata.InstanceAction][]sagadata.InstanceStatus{
+				sagadata.InstanceActionReset: {sagadata.InstanceStatusActive},
+			},
+			action:            sagadata.InstanceActionReset,
+			expectedPerformed: []string{"reset in active"},
+			expectedStatuses:  []sagadata.InstanceStatus{"active", "active", "active", "active", "active", "active", "active"},
+		},
+		"start after stopping": {
+			statuses:          []sagadata.InstanceStatus{sagadata.InstanceStatusStopping, sagadata.InstanceStatusStopped},
+			action:            sagadata.InstanceActionStart,
+			expectedPerformed: []string{"start in stopped"},
+			expectedStatuses:  []sagadata.InstanceStatus{"stopping", "stopped", "stopped", "stopped", "starting", "active"},
+		},
+		"action fails": {
+			statuses:          []sagadata.InstanceStatus{sagadata.InstanceStatusActive},
+			action:            "hibernate",
+			expectedPerformed: []string{"hibernate in active"},
+			expectedStatuses:  []sagadata.InstanceStatus{"active"},
+			expectError:       true,
+		},
+		"status transition": {
+			statuses:          []sagadata.InstanceStatus{sagadata.InstanceStatusShelving, sagadata.InstanceStatusShelved},
+			target:            sagadata.InstanceStatusStopped,
+			expectedPerformed: []string{"unshelve in shelved", "stop in active"},
+			expectedStatuses:  []sagadata.InstanceStatus{"shelving", "shelved", "shelved", "shelved", "unshelving", "active", "active", "active", "stopping", "stopped"},
+		},
+		"status transition done": {
+			statuses:         []sagadata.InstanceStatus{sagadata.InstanceStatusStopped},
+			target:           sagadata.InstanceStatusStopped,
+			expectedStatuses: []sagadata.InstanceStatus{"stopped"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			transitions := testCase.transitions
+			if transitions == nil {
+				transitions = fakeInstanceTransitions
+			}
+
+			client := &fakeInstanceClient{
+				statuses:    testCase.statuses,
+				transitions: transitions,
+				lag:         2,
+			}
+
+			var statuses []sagadata.InstanceStatus
+			observe := func(instance *sagadata.Instance) diag.Diagnostics {
+				statuses = append(statuses, instance.Status)
+				return nil
+			}
+
+			var diags diag.Diagnostics
+			if testCase.action != "" {
+				diags = transitionInstance(ctx, client, "instance-id", instanceActionTargetStatus(testCase.action), instanceActionStep(testCase.action), observe)
+			} else {
+				diags = transitionInstanceStatus(ctx, client, "instance-id", testCase.target, observe)
+			}
+
+			if diags.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got %v", testCase.expectError, diags)
+			}
+
+			if fmt.Sprint(client.performed) != fmt.Sprint(testCase.expectedPerformed) {
+				t.Errorf("expected performed %v, got %v", testCase.expectedPerformed, client.performed)
+			}
+
+			if fmt.Sprint(statuses) != fmt.Sprint(testCase.expectedStatuses) {
+				t.Errorf("expected statuses %v, got %v", testCase.expectedStatuses, statuses)
+			}
+		})
+	}
+}
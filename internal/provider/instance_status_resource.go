@@ -2,18 +2,20 @@ package provider
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sagadata-public/sagadata-go"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -22,6 +24,8 @@ var (
 	_ resource.Resource                = &InstanceStatusResource{}
 	_ resource.ResourceWithConfigure   = &InstanceStatusResource{}
 	_ resource.ResourceWithImportState = &InstanceStatusResource{}
+	_ resource.ResourceWithMoveState   = &InstanceStatusResource{}
+	_ resource.ResourceWithIdentity    = &InstanceStatusResource{}
 )
 
 func NewInstanceStatusResource() resource.Resource {
@@ -52,10 +56,10 @@ func (r *InstanceStatusResource) Schema(ctx context.Context, req resource.Schema
 				},
 			}),
 			"status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "The target instance status.",
+				MarkdownDescription: "The target instance status. Transient statuses, e.g. `starting`, are waited out before the instance is transitioned.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(string(sagadata.InstanceStatusActive), string(sagadata.InstanceStatusStopped)),
+					stringvalidator.OneOf(sliceStringify(instanceStatusTargets)...),
 				},
 			}),
 
@@ -65,6 +69,17 @@ func (r *InstanceStatusResource) Schema(ctx context.Context, req resource.Schema
 	}
 }
 
+func (r *InstanceStatusResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"instance_id": identityschema.StringAttribute{
+				Description:       "The id of the instance this refers to.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *InstanceStatusResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data InstanceStatusResourceModel
 
@@ -74,7 +89,7 @@ func (r *InstanceStatusResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_instance_status").Create)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -84,118 +99,9 @@ func (r *InstanceStatusResource) Create(ctx context.Context, req resource.Create
 	instanceId := data.InstanceId.ValueString()
 	targetStatus := sagadata.InstanceStatus(data.Status.ValueString())
 
-	var instanceAction sagadata.InstanceAction
-
-	{
-		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("get instance status", err))
-			return
-		}
-
-		instanceResponse := response.JSON200
-		if instanceResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("get instance status", ErrorResponse{
-				Body:         response.Body,
-				HTTPResponse: response.HTTPResponse,
-				Error:        response.JSONDefault,
-			}))
-			return
-		}
-
-		resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		// Save data into Terraform state
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		if instanceResponse.Instance.Status == targetStatus {
-			return
-		}
-
-		if targetStatus == sagadata.InstanceStatusActive &&
-			instanceResponse.Instance.Status == sagadata.InstanceStatusStopped {
-
-			instanceAction = sagadata.InstanceActionStart
-		} else if targetStatus == sagadata.InstanceStatusStopped &&
-			(instanceResponse.Instance.Status == sagadata.InstanceStatusActive ||
-				instanceResponse.Instance.Status == sagadata.InstanceStatusStarting ||
-				instanceResponse.Instance.Status == sagadata.InstanceStatusError) {
-
-			instanceAction = sagadata.InstanceActionStop
-		} else {
-			resp.Diagnostics.AddError("Cannot transition instance status",
-				fmt.Sprintf("The instance resource with id %q cannot be transitioned from %q status to %q status. If the current status is transient (ending in 'ing' e.g. stopping) waiting a bit is usually enough.",
-					instanceId, instanceResponse.Instance.Status, targetStatus))
-			return
-		}
-	}
-
-	body := sagadata.PerformInstanceActionJSONRequestBody{}
-	body.Action = instanceAction
-
-	response, err := r.client.PerformInstanceActionWithResponse(ctx, instanceId, body)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", generateErrorMessage("perform instance action", err))
-		return
-	}
-
-	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("perform instance action", ErrorResponse{
-			Body:         response.Body,
-			HTTPResponse: response.HTTPResponse,
-			Error:        response.JSONDefault,
-		}))
-		return
-	}
-
-	tflog.Trace(ctx, "performed instance action", map[string]interface{}{"action": body.Action})
-
-	for {
-		err := r.client.PollingWait(ctx)
-		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling instance status", err))
-			return
-		}
-
-		tflog.Trace(ctx, "polling instance status")
-
-		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling instance status", err))
-			return
-		}
-
-		instanceResponse := response.JSON200
-		if instanceResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling instance status", ErrorResponse{
-				Body:         response.Body,
-				HTTPResponse: response.HTTPResponse,
-				Error:        response.JSONDefault,
-			}))
-			return
-		}
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("instance_id"), data.InstanceId)...)
 
-		resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		// Save data into Terraform state
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		if instanceResponse.Instance.Status == targetStatus {
-			return
-		}
-	}
+	resp.Diagnostics.Append(transitionInstanceStatus(ctx, r.client, instanceId, targetStatus, saveInstanceStatus(ctx, &data, &resp.State))...)
 }
 
 func (r *InstanceStatusResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -207,7 +113,7 @@ func (r *InstanceStatusResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_instance_status").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -216,7 +122,7 @@ func (r *InstanceStatusResource) Read(ctx context.Context, req resource.ReadRequ
 
 	instanceId := data.InstanceId.ValueString()
 
-	response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
+	response, err := r.client.GetInstanceCachedWithResponse(ctx, instanceId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read instance status", err))
 		return
@@ -224,7 +130,7 @@ func (r *InstanceStatusResource) Read(ctx context.Context, req resource.ReadRequ
 
 	instanceResponse := response.JSON200
 	if instanceResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read instance status", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read instance status", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -239,6 +145,8 @@ func (r *InstanceStatusResource) Read(ctx context.Context, req resource.ReadRequ
 
 	tflog.Trace(ctx, "read instance status resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("instance_id"), data.InstanceId)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -252,7 +160,7 @@ func (r *InstanceStatusResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update, r.client.DefaultTimeouts("sagadata_instance_status").Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -262,117 +170,21 @@ func (r *InstanceStatusResource) Update(ctx context.Context, req resource.Update
 	instanceId := data.InstanceId.ValueString()
 	targetStatus := sagadata.InstanceStatus(data.Status.ValueString())
 
-	var instanceAction sagadata.InstanceAction
-
-	{
-		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("get instance status", err))
-			return
-		}
-
-		instanceResponse := response.JSON200
-		if instanceResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("get instance status", ErrorResponse{
-				Body:         response.Body,
-				HTTPResponse: response.HTTPResponse,
-				Error:        response.JSONDefault,
-			}))
-			return
-		}
-
-		resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		// Save data into Terraform state
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		if instanceResponse.Instance.Status == targetStatus {
-			return
-		}
-
-		if targetStatus == sagadata.InstanceStatusActive &&
-			instanceResponse.Instance.Status == sagadata.InstanceStatusStopped {
-
-			instanceAction = sagadata.InstanceActionStart
-		} else if targetStatus == sagadata.InstanceStatusStopped &&
-			(instanceResponse.Instance.Status == sagadata.InstanceStatusActive ||
-				instanceResponse.Instance.Status == sagadata.InstanceStatusStarting ||
-				instanceResponse.Instance.Status == sagadata.InstanceStatusError) {
-
-			instanceAction = sagadata.InstanceActionStop
-		} else {
-			resp.Diagnostics.AddError("Cannot transition instance status",
-				fmt.Sprintf("The instance resource with id %q cannot be transitioned from %q status to %q status. If the current status is transient (ending in 'ing' e.g. stopping) waiting a bit is usually enough.",
-					instanceId, instanceResponse.Instance.Status, targetStatus))
-			return
-		}
-	}
-
-	body := sagadata.PerformInstanceActionJSONRequestBody{}
-	body.Action = instanceAction
-
-	response, err := r.client.PerformInstanceActionWithResponse(ctx, instanceId, body)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", generateErrorMessage("perform instance action", err))
-		return
-	}
-
-	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("perform instance action", ErrorResponse{
-			Body:         response.Body,
-			HTTPResponse: response.HTTPResponse,
-			Error:        response.JSONDefault,
-		}))
-		return
-	}
-
-	tflog.Trace(ctx, "performed instance action", map[string]interface{}{"action": body.Action})
-
-	for {
-		err := r.client.PollingWait(ctx)
-		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling instance status", err))
-			return
-		}
-
-		tflog.Trace(ctx, "polling instance status")
-
-		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling instance status", err))
-			return
-		}
-
-		instanceResponse := response.JSON200
-		if instanceResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling instance status", ErrorResponse{
-				Body:         response.Body,
-				HTTPResponse: response.HTTPResponse,
-				Error:        response.JSONDefault,
-			}))
-			return
-		}
+	resp.Diagnostics.Append(transitionInstanceStatus(ctx, r.client, instanceId, targetStatus, saveInstanceStatus(ctx, &data, &resp.State))...)
+}
 
-		resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-		if resp.Diagnostics.HasError() {
-			return
+// saveInstanceStatus returns a function which saves each polled instance into
+// the state during transitions.
+func saveInstanceStatus(ctx context.Context, data *InstanceStatusResourceModel, state *tfsdk.State) func(instance *sagadata.Instance) diag.Diagnostics {
+	return func(instance *sagadata.Instance) diag.Diagnostics {
+		diags := data.PopulateFromClientResponse(ctx, instance)
+		if diags.HasError() {
+			return diags
 		}
 
 		// Save data into Terraform state
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		if instanceResponse.Instance.Status == targetStatus {
-			return
-		}
+		diags.Append(state.Set(ctx, data)...)
+		return diags
 	}
 }
 
@@ -389,5 +201,11 @@ func (r *InstanceStatusResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *InstanceStatusResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("instance_id"), req, resp)
+	importStateByName(ctx, req, resp, path.Root("instance_id"), "instance", r.client, listInstanceImportCandidates)
+}
+
+func (r *InstanceStatusResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		genesisCloudStateMover("_instance_status", nil),
+	}
 }
@@ -17,12 +17,37 @@ type InstanceMetadataModel struct {
 	StartupScript types.String `tfsdk:"startup_script"`
 }
 
+type InstanceCapacityFallbackModel struct {
+	// PlacementOptions The placement options to try after the `placement_option`.
+	PlacementOptions types.List `tfsdk:"placement_options"`
+
+	// Types The instance types to try after the `type`.
+	Types types.List `tfsdk:"types"`
+
+	// Retry Whether to retry with a backoff until the create timeout expires.
+	Retry types.Bool `tfsdk:"retry"`
+}
+
+type InstanceWaitForModel struct {
+	// TcpPort The TCP port which has to accept connections.
+	TcpPort types.Int64 `tfsdk:"tcp_port"`
+
+	// Address The IP address to connect to, `public_ip` or `private_ip`.
+	Address types.String `tfsdk:"address"`
+
+	// Timeout How long to wait for the instance to be ready.
+	Timeout types.String `tfsdk:"timeout"`
+}
+
 type InstanceResourceModel struct {
 	CreatedAt types.String `tfsdk:"created_at"`
 
 	// Hostname The hostname of your instance.
 	Hostname types.String `tfsdk:"hostname"`
 
+	// DesiredStatus The status to keep the instance in.
+	DesiredStatus types.String `tfsdk:"desired_status"`
+
 	// DnsName The dns name of your instance.
 	DnsName types.String `tfsdk:"dns_name"`
 
@@ -91,8 +116,23 @@ type InstanceResourceModel struct {
 	// K8sClusterId The Kubernetes cluster this instance belongs to.
 	K8sClusterId types.String `tfsdk:"k8s_cluster_id"`
 
+	// CapacityFallback The alternatives to try when there is no capacity for the instance.
+	CapacityFallback *InstanceCapacityFallbackModel `tfsdk:"capacity_fallback"`
+
+	// WaitFor How to wait for the instance to be usable after it is created.
+	WaitFor *InstanceWaitForModel `tfsdk:"wait_for"`
+
+	// SelectedType The instance type the instance was created with.
+	SelectedType types.String `tfsdk:"selected_type"`
+
+	// SelectedPlacementOption The placement option the instance was created in.
+	SelectedPlacementOption types.String `tfsdk:"selected_placement_option"`
+
 	// Internal
 
+	// DeletionProtection Flag to prevent the instance from being deleted.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
 	// Timeouts The resource timeouts
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
@@ -102,7 +142,14 @@ func (data *InstanceResourceModel) PopulateFromClientResponse(ctx context.Contex
 	data.Name = types.StringValue(instance.Name)
 	data.Hostname = types.StringValue(instance.Hostname)
 	data.DnsName = types.StringValue(instance.DnsName)
-	data.Type = types.StringValue(string(instance.Type))
+
+	// The configured type is kept when the instance was created with a fallback type
+	data.SelectedType = types.StringValue(string(instance.Type))
+	if data.CapacityFallback == nil || data.Type.IsNull() || data.Type.IsUnknown() ||
+		!capacityFallbackContains(ctx, data.CapacityFallback.Types, string(instance.Type)) {
+		data.Type = data.SelectedType
+	}
+
 	data.ImageId = types.StringValue(instance.Image.Id)
 
 	volumeIds := make([]string, 0) // volumes do NOT support NULL
@@ -132,7 +179,12 @@ func (data *InstanceResourceModel) PopulateFromClientResponse(ctx context.Contex
 		return
 	}
 
-	data.PlacementOption = types.StringValue(string(instance.PlacementOption))
+	// The configured placement option is kept when the instance was created in a fallback placement option
+	data.SelectedPlacementOption = types.StringValue(string(instance.PlacementOption))
+	if data.CapacityFallback == nil || data.PlacementOption.IsNull() || data.PlacementOption.IsUnknown() ||
+		!capacityFallbackContains(ctx, data.CapacityFallback.PlacementOptions, string(instance.PlacementOption)) {
+		data.PlacementOption = data.SelectedPlacementOption
+	}
 
 	if instance.PrivateIp != nil {
 		data.PrivateIp = types.StringValue(*instance.PrivateIp)
@@ -83,7 +83,7 @@ func (d *KubernetesClusterDataSource) Read(ctx context.Context, req datasource.R
 		return
 	}
 
-	ctx, cancel, diag := d.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := d.ContextWithTimeout(ctx, data.Timeouts.Read, d.client.DefaultTimeouts("sagadata_kubernetes_cluster").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -101,7 +101,7 @@ func (d *KubernetesClusterDataSource) Read(ctx context.Context, req datasource.R
 
 	clusterData := clusterResponse.JSON200
 	if clusterData == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read kubernetes cluster", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read kubernetes cluster", nil, ErrorResponse{
 			Body:         clusterResponse.Body,
 			HTTPResponse: clusterResponse.HTTPResponse,
 			Error:        clusterResponse.JSONDefault,
@@ -123,7 +123,7 @@ func (d *KubernetesClusterDataSource) Read(ctx context.Context, req datasource.R
 
 	credsData := credsResponse.JSON200
 	if credsData == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read kubernetes cluster credentials", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read kubernetes cluster credentials", nil, ErrorResponse{
 			Body:         credsResponse.Body,
 			HTTPResponse: credsResponse.HTTPResponse,
 			Error:        credsResponse.JSONDefault,
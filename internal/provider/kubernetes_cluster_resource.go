@@ -6,6 +6,7 @@ import (
 	"github.com/sagadata-public/sagadata-go"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -18,9 +19,18 @@ import (
 var (
 	_ resource.Resource                = &KubernetesClusterResource{}
 	_ resource.ResourceWithConfigure   = &KubernetesClusterResource{}
+	_ resource.ResourceWithModifyPlan  = &KubernetesClusterResource{}
 	_ resource.ResourceWithImportState = &KubernetesClusterResource{}
+	_ resource.ResourceWithMoveState   = &KubernetesClusterResource{}
+	_ resource.ResourceWithIdentity    = &KubernetesClusterResource{}
 )
 
+// kubernetesClusterErrorFieldAttributes maps the fields of the Kubernetes cluster requests to their attributes.
+var kubernetesClusterErrorFieldAttributes = map[string]string{
+	"name":    "name",
+	"network": "network",
+}
+
 func NewKubernetesClusterResource() resource.Resource {
 	return &KubernetesClusterResource{}
 }
@@ -29,6 +39,7 @@ func NewKubernetesClusterResource() resource.Resource {
 type KubernetesClusterResource struct {
 	ResourceWithClient
 	ResourceWithTimeout
+	ResourceWithIdIdentity
 }
 
 func (r *KubernetesClusterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -77,12 +88,18 @@ func (r *KubernetesClusterResource) Schema(ctx context.Context, req resource.Sch
 				Computed:            true,
 			}),
 
+			"deletion_protection": deletionProtectionAttribute(ctx, "Kubernetes cluster"),
+
 			// Internal
 			"timeouts": timeouts.AttributesAll(ctx),
 		},
 	}
 }
 
+func (r *KubernetesClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanDeletionProtection(ctx, "Kubernetes cluster", req, resp)
+}
+
 func (r *KubernetesClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data KubernetesClusterResourceModel
 
@@ -91,7 +108,7 @@ func (r *KubernetesClusterResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_kubernetes_cluster").Create)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -114,7 +131,7 @@ func (r *KubernetesClusterResource) Create(ctx context.Context, req resource.Cre
 
 	clusterResponse := response.JSON201
 	if clusterResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("create kubernetes cluster", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("create kubernetes cluster", kubernetesClusterErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -129,6 +146,8 @@ func (r *KubernetesClusterResource) Create(ctx context.Context, req resource.Cre
 
 	tflog.Trace(ctx, "created a kubernetes cluster resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -136,47 +155,68 @@ func (r *KubernetesClusterResource) Create(ctx context.Context, req resource.Cre
 
 	clusterId := clusterResponse.Cluster.Id
 
+	cluster, diags := r.waitForCreated(ctx, clusterId)
+	if diags.HasError() && isWaitTimeout(ctx) {
+		resp.Diagnostics.Append(markProvisioning(ctx, resp.Private, "Kubernetes cluster")...)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, cluster)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if cluster.Status == sagadata.KubernetesClusterStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling kubernetes cluster", ErrResourceInErrorState))
+	}
+}
+
+// kubernetesClusterProvisioned returns whether the Kubernetes cluster finished provisioning.
+func kubernetesClusterProvisioned(cluster *sagadata.KubernetesCluster) bool {
+	return cluster.Status == sagadata.KubernetesClusterStatusActive || cluster.Status == sagadata.KubernetesClusterStatusError
+}
+
+// waitForCreated polls the Kubernetes cluster until it finished provisioning.
+func (r *KubernetesClusterResource) waitForCreated(ctx context.Context, clusterId string) (*sagadata.KubernetesCluster, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	for {
 		err := r.client.PollingWait(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling kubernetes cluster", err))
-			return
+			diags.AddError("Polling Error", generateErrorMessage("polling kubernetes cluster", err))
+			return nil, diags
 		}
 
 		tflog.Trace(ctx, "polling a kubernetes cluster resource")
 
 		response, err := r.client.GetKubernetesClusterWithResponse(ctx, clusterId)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling kubernetes cluster", err))
-			return
+			diags.AddError("Client Error", generateErrorMessage("polling kubernetes cluster", err))
+			return nil, diags
 		}
 
 		clusterResponse := response.JSON200
 		if clusterResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling kubernetes cluster", ErrorResponse{
+			diags.Append(newClientErrorDiagnostic("polling kubernetes cluster", nil, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
 			}))
-			return
+			return nil, diags
 		}
 
-		status := clusterResponse.Cluster.Status
-		if status == sagadata.KubernetesClusterStatusActive || status == sagadata.KubernetesClusterStatusError {
-			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &clusterResponse.Cluster)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			if status == sagadata.KubernetesClusterStatusError {
-				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling kubernetes cluster", ErrResourceInErrorState))
-			}
-			return
+		if kubernetesClusterProvisioned(&clusterResponse.Cluster) {
+			return &clusterResponse.Cluster, diags
 		}
 	}
 }
@@ -189,7 +229,7 @@ func (r *KubernetesClusterResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_kubernetes_cluster").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -198,7 +238,7 @@ func (r *KubernetesClusterResource) Read(ctx context.Context, req resource.ReadR
 
 	clusterId := data.Id.ValueString()
 
-	response, err := r.client.GetKubernetesClusterWithResponse(ctx, clusterId)
+	response, err := r.client.GetKubernetesClusterCachedWithResponse(ctx, clusterId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read kubernetes cluster", err))
 		return
@@ -206,7 +246,7 @@ func (r *KubernetesClusterResource) Read(ctx context.Context, req resource.ReadR
 
 	clusterResponse := response.JSON200
 	if clusterResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read kubernetes cluster", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read kubernetes cluster", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -214,13 +254,21 @@ func (r *KubernetesClusterResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &clusterResponse.Cluster)...)
+	cluster, diags := resumeProvisioning(ctx, resp.Private, "Kubernetes cluster", clusterId, &clusterResponse.Cluster, kubernetesClusterProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, cluster)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Trace(ctx, "read a kubernetes cluster resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -232,13 +280,19 @@ func (r *KubernetesClusterResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update, r.client.DefaultTimeouts("sagadata_kubernetes_cluster").Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 	defer cancel()
 
+	_, diags := resumeProvisioning(ctx, resp.Private, "Kubernetes cluster", data.Id.ValueString(), nil, kubernetesClusterProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	body := sagadata.UpdateKubernetesClusterJSONRequestBody{}
 
 	if !data.Network.IsNull() && !data.Network.IsUnknown() {
@@ -255,7 +309,7 @@ func (r *KubernetesClusterResource) Update(ctx context.Context, req resource.Upd
 
 	clusterResponse := response.JSON200
 	if clusterResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("update kubernetes cluster", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("update kubernetes cluster", kubernetesClusterErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -281,7 +335,7 @@ func (r *KubernetesClusterResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete, r.client.DefaultTimeouts("sagadata_kubernetes_cluster").Delete)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -290,6 +344,11 @@ func (r *KubernetesClusterResource) Delete(ctx context.Context, req resource.Del
 
 	clusterId := data.Id.ValueString()
 
+	resp.Diagnostics.Append(checkDeletionProtection("Kubernetes cluster", clusterId, data.DeletionProtection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	response, err := r.client.DeleteKubernetesClusterWithResponse(ctx, clusterId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("delete kubernetes cluster", err))
@@ -297,7 +356,7 @@ func (r *KubernetesClusterResource) Delete(ctx context.Context, req resource.Del
 	}
 
 	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("delete kubernetes cluster", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("delete kubernetes cluster", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -327,5 +386,11 @@ func (r *KubernetesClusterResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *KubernetesClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+func (r *KubernetesClusterResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		genesisCloudStateMover("_kubernetes_cluster", nil),
+	}
 }
@@ -28,6 +28,9 @@ type KubernetesClusterResourceModel struct {
 
 	UpdatedAt types.String `tfsdk:"updated_at"`
 
+	// DeletionProtection Flag to prevent the Kubernetes cluster from being deleted.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
 	// Timeouts The resource timeouts
 	Timeouts resourcetimeouts.Value `tfsdk:"timeouts"`
 }
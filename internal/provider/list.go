@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const listPerPage = 100
+
+// listAllPages fetches pages until a page is not full and returns the items of all pages.
+func listAllPages[T any](ctx context.Context, kind string, fetch func(page int) ([]T, *ErrorResponse, error)) (items []T, diags diag.Diagnostics) {
+	verb := fmt.Sprintf("list %s", kind)
+
+	for page := 1; ; page++ {
+		pageItems, errorResponse, err := fetch(page)
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage(verb, err))
+			return nil, diags
+		}
+
+		if errorResponse != nil {
+			diags.Append(newClientErrorDiagnostic(verb, nil, *errorResponse))
+			return nil, diags
+		}
+
+		items = append(items, pageItems...)
+
+		if len(pageItems) < listPerPage {
+			// pagination done
+			return items, diags
+		}
+	}
+}
+
+func listInstances(ctx context.Context, client *Client) ([]sagadata.Instance, diag.Diagnostics) {
+	return listAllPages(ctx, "instances", func(page int) ([]sagadata.Instance, *ErrorResponse, error) {
+		response, err := client.ListInstancesPaginatedWithResponse(ctx, &sagadata.ListInstancesPaginatedParams{
+			Page:    pointer(page),
+			PerPage: pointer(listPerPage),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if response.JSON200 == nil {
+			return nil, &ErrorResponse{Body: response.Body, HTTPResponse: response.HTTPResponse, Error: response.JSONDefault}, nil
+		}
+
+		return response.JSON200.Instances, nil, nil
+	})
+}
+
+func listVolumes(ctx context.Context, client *Client) ([]sagadata.Volume, diag.Diagnostics) {
+	return listAllPages(ctx, "volumes", func(page int) ([]sagadata.Volume, *ErrorResponse, error) {
+		response, err := client.ListVolumesPaginatedWithResponse(ctx, &sagadata.ListVolumesPaginatedParams{
+			Page:    pointer(page),
+			PerPage: pointer(listPerPage),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if response.JSON200 == nil {
+			return nil, &ErrorResponse{Body: response.Body, HTTPResponse: response.HTTPResponse, Error: response.JSONDefault}, nil
+		}
+
+		return response.JSON200.Volumes, nil, nil
+	})
+}
+
+func listFilesystems(ctx context.Context, client *Client) ([]sagadata.Filesystem, diag.Diagnostics) {
+	return listAllPages(ctx, "filesystems", func(page int) ([]sagadata.Filesystem, *ErrorResponse, error) {
+		response, err := client.ListFilesystemsPaginatedWithResponse(ctx, &sagadata.ListFilesystemsPaginatedParams{
+			Page:    pointer(page),
+			PerPage: pointer(listPerPage),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if response.JSON200 == nil {
+			return nil, &ErrorResponse{Body: response.Body, HTTPResponse: response.HTTPResponse, Error: response.JSONDefault}, nil
+		}
+
+		return response.JSON200.Filesystems, nil, nil
+	})
+}
+
+func listSecurityGroups(ctx context.Context, client *Client) ([]sagadata.SecurityGroup, diag.Diagnostics) {
+	return listAllPages(ctx, "security groups", func(page int) ([]sagadata.SecurityGroup, *ErrorResponse, error) {
+		response, err := client.ListSecurityGroupsPaginatedWithResponse(ctx, &sagadata.ListSecurityGroupsPaginatedParams{
+			Page:    pointer(page),
+			PerPage: pointer(listPerPage),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if response.JSON200 == nil {
+			return nil, &ErrorResponse{Body: response.Body, HTTPResponse: response.HTTPResponse, Error: response.JSONDefault}, nil
+		}
+
+		return response.JSON200.SecurityGroups, nil, nil
+	})
+}
+
+func listSnapshots(ctx context.Context, client *Client) ([]sagadata.Snapshot, diag.Diagnostics) {
+	return listAllPages(ctx, "snapshots", func(page int) ([]sagadata.Snapshot, *ErrorResponse, error) {
+		response, err := client.ListSnapshotsPaginatedWithResponse(ctx, &sagadata.ListSnapshotsPaginatedParams{
+			Page:    pointer(page),
+			PerPage: pointer(listPerPage),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if response.JSON200 == nil {
+			return nil, &ErrorResponse{Body: response.Body, HTTPResponse: response.HTTPResponse, Error: response.JSONDefault}, nil
+		}
+
+		return response.JSON200.Snapshots, nil, nil
+	})
+}
+
+func listSSHKeys(ctx context.Context, client *Client) ([]sagadata.SSHKey, diag.Diagnostics) {
+	return listAllPages(ctx, "ssh keys", func(page int) ([]sagadata.SSHKey, *ErrorResponse, error) {
+		response, err := client.ListSSHKeysPaginatedWithResponse(ctx, &sagadata.ListSSHKeysPaginatedParams{
+			Page:    pointer(page),
+			PerPage: pointer(listPerPage),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if response.JSON200 == nil {
+			return nil, &ErrorResponse{Body: response.Body, HTTPResponse: response.HTTPResponse, Error: response.JSONDefault}, nil
+		}
+
+		return response.JSON200.SSHKeys, nil, nil
+	})
+}
+
+func listFloatingIPs(ctx context.Context, client *Client) ([]sagadata.FloatingIP, diag.Diagnostics) {
+	return listAllPages(ctx, "floating ips", func(page int) ([]sagadata.FloatingIP, *ErrorResponse, error) {
+		response, err := client.ListFloatingIPsPaginatedWithResponse(ctx, &sagadata.ListFloatingIPsPaginatedParams{
+			Page:    pointer(page),
+			PerPage: pointer(listPerPage),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if response.JSON200 == nil {
+			return nil, &ErrorResponse{Body: response.Body, HTTPResponse: response.HTTPResponse, Error: response.JSONDefault}, nil
+		}
+
+		return response.JSON200.FloatingIPs, nil, nil
+	})
+}
+
+func listPrivateNetworks(ctx context.Context, client *Client) ([]sagadata.PrivateNetwork, diag.Diagnostics) {
+	return listAllPages(ctx, "private networks", func(page int) ([]sagadata.PrivateNetwork, *ErrorResponse, error) {
+		response, err := client.ListPrivateNetworksPaginatedWithResponse(ctx, &sagadata.ListPrivateNetworksPaginatedParams{
+			Page:    pointer(page),
+			PerPage: pointer(listPerPage),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if response.JSON200 == nil {
+			return nil, &ErrorResponse{Body: response.Body, HTTPResponse: response.HTTPResponse, Error: response.JSONDefault}, nil
+		}
+
+		return response.JSON200.PrivateNetworks, nil, nil
+	})
+}
+
+func listKubernetesClusters(ctx context.Context, client *Client) ([]sagadata.KubernetesCluster, diag.Diagnostics) {
+	return listAllPages(ctx, "kubernetes clusters", func(page int) ([]sagadata.KubernetesCluster, *ErrorResponse, error) {
+		response, err := client.ListKubernetesClustersPaginatedWithResponse(ctx, &sagadata.ListKubernetesClustersPaginatedParams{
+			Page:    pointer(page),
+			PerPage: pointer(listPerPage),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if response.JSON200 == nil {
+			return nil, &ErrorResponse{Body: response.Body, HTTPResponse: response.HTTPResponse, Error: response.JSONDefault}, nil
+		}
+
+		return response.JSON200.KubernetesClusters, nil, nil
+	})
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ list.ListResource              = &ResourceListResource{}
+	_ list.ListResourceWithConfigure = &ResourceListResource{}
+)
+
+func NewInstanceListResource() list.ListResource {
+	return &ResourceListResource{typeNameSuffix: "_instance", regional: true, newResource: NewInstanceResource, list: listInstanceImportCandidates}
+}
+
+func NewVolumeListResource() list.ListResource {
+	return &ResourceListResource{typeNameSuffix: "_volume", regional: true, newResource: NewVolumeResource, list: listVolumeImportCandidates}
+}
+
+func NewFilesystemListResource() list.ListResource {
+	return &ResourceListResource{typeNameSuffix: "_filesystem", regional: true, newResource: NewFilesystemResource, list: listFilesystemImportCandidates}
+}
+
+func NewSecurityGroupListResource() list.ListResource {
+	return &ResourceListResource{typeNameSuffix: "_security_group", regional: true, newResource: NewSecurityGroupResource, list: listSecurityGroupImportCandidates}
+}
+
+func NewSnapshotListResource() list.ListResource {
+	return &ResourceListResource{typeNameSuffix: "_snapshot", regional: true, newResource: NewSnapshotResource, list: listSnapshotImportCandidates}
+}
+
+func NewSSHKeyListResource() list.ListResource {
+	return &ResourceListResource{typeNameSuffix: "_ssh_key", newResource: NewSSHKeyResource, list: listSSHKeyImportCandidates}
+}
+
+func NewFloatingIPListResource() list.ListResource {
+	return &ResourceListResource{typeNameSuffix: "_floating_ip", regional: true, newResource: NewFloatingIPResource, list: listFloatingIPImportCandidates}
+}
+
+func NewPrivateNetworkListResource() list.ListResource {
+	return &ResourceListResource{typeNameSuffix: "_private_network", regional: true, newResource: NewPrivateNetworkResource, list: listPrivateNetworkImportCandidates}
+}
+
+func NewKubernetesClusterListResource() list.ListResource {
+	return &ResourceListResource{typeNameSuffix: "_kubernetes_cluster", newResource: NewKubernetesClusterResource, list: listKubernetesClusterImportCandidates}
+}
+
+// ResourceListResource lists all resources of a resource type in the account
+// for bulk discovery. The resources are identified by their id.
+type ResourceListResource struct {
+	ResourceWithClient
+
+	// typeNameSuffix The suffix of the matching resource type, e.g. "_volume".
+	typeNameSuffix string
+
+	// regional Whether the resources can be filtered by region.
+	regional bool
+
+	// newResource Creates the matching resource which is used to read the full resource.
+	newResource func() resource.Resource
+
+	// list Lists the resources.
+	list func(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics)
+}
+
+func (r *ResourceListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.typeNameSuffix
+}
+
+func (r *ResourceListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{},
+	}
+
+	if r.regional {
+		resp.Schema.Attributes["region"] = schema.StringAttribute{
+			MarkdownDescription: "Filter by the region identifier.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(sliceStringify(sagadata.AllRegions)...),
+			},
+		}
+	}
+}
+
+func (r *ResourceListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var region types.String
+
+	if r.regional {
+		diags := req.Config.GetAttribute(ctx, path.Root("region"), &region)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+
+	candidates, diags := r.list(ctx, r.client)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+
+		for _, candidate := range candidates {
+			if !region.IsNull() && candidate.Region != region.ValueString() {
+				continue
+			}
+
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			count++
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = candidate.Name
+
+			result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root("id"), candidate.Id)...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				result.Diagnostics.Append(r.readResource(ctx, candidate.Id, result.Resource)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// readResource populates the resource through the Read of the matching resource.
+func (r *ResourceListResource) readResource(ctx context.Context, id string, target *tfsdk.Resource) (diags diag.Diagnostics) {
+	res := r.newResource()
+
+	if resourceWithConfigure, ok := res.(resource.ResourceWithConfigure); ok {
+		configureResp := &resource.ConfigureResponse{}
+		resourceWithConfigure.Configure(ctx, resource.ConfigureRequest{ProviderData: r.client}, configureResp)
+
+		diags.Append(configureResp.Diagnostics...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	state := tfsdk.State{
+		Schema: target.Schema,
+		Raw:    target.Raw,
+	}
+
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), id)...)
+	if diags.HasError() {
+		return
+	}
+
+	readResp := &resource.ReadResponse{State: state}
+	res.Read(ctx, resource.ReadRequest{State: state}, readResp)
+
+	diags.Append(readResp.Diagnostics...)
+	if diags.HasError() {
+		return
+	}
+
+	target.Raw = readResp.State.Raw
+	return
+}
@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeListedResource is a resource whose Read names the resource after its id.
+type fakeListedResource struct{}
+
+func (r *fakeListedResource) Metadata(ctx context.Context, req fwresource.MetadataRequest, resp *fwresource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fake"
+}
+
+func (r *fakeListedResource) Schema(ctx context.Context, req fwresource.SchemaRequest, resp *fwresource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":   schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+func (r *fakeListedResource) Create(ctx context.Context, req fwresource.CreateRequest, resp *fwresource.CreateResponse) {
+}
+
+func (r *fakeListedResource) Read(ctx context.Context, req fwresource.ReadRequest, resp *fwresource.ReadResponse) {
+	var id types.String
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if id.ValueString() == "missing" {
+		resp.Diagnostics.AddError("Client Error", "not found")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), "read "+id.ValueString())...)
+}
+
+func (r *fakeListedResource) Update(ctx context.Context, req fwresource.UpdateRequest, resp *fwresource.UpdateResponse) {
+}
+
+func (r *fakeListedResource) Delete(ctx context.Context, req fwresource.DeleteRequest, resp *fwresource.DeleteResponse) {
+}
+
+func TestResourceListResourceList(t *testing.T) {
+	ctx := context.Background()
+
+	r := &ResourceListResource{
+		typeNameSuffix: "_fake",
+		regional:       true,
+		newResource:    func() fwresource.Resource { return &fakeListedResource{} },
+		list: func(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+			return []importCandidate{
+				{Id: "00000000-0000-0000-0000-000000000001", Name: "data", Region: "NORD-NO-KRS-1"},
+				{Id: "00000000-0000-0000-0000-000000000002", Name: "data", Region: "EUW-NL-AMS-1"},
+				{Id: "00000000-0000-0000-0000-000000000003", Name: "scratch", Region: "NORD-NO-KRS-1"},
+			}, nil
+		},
+	}
+
+	configSchemaResp := &list.ListResourceSchemaResponse{}
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, configSchemaResp)
+
+	schemaResp := &fwresource.SchemaResponse{}
+	(&fakeListedResource{}).Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	identitySchemaResp := &fwresource.IdentitySchemaResponse{}
+	(&ResourceWithIdIdentity{}).IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identitySchemaResp)
+
+	testCases := map[string]struct {
+		region          string
+		limit           int64
+		includeResource bool
+		expectedIds     []string
+		expectedNames   []string
+	}{
+		"all": {
+			expectedIds: []string{
+				"00000000-0000-0000-0000-000000000001",
+				"00000000-0000-0000-0000-000000000002",
+				"00000000-0000-0000-0000-000000000003",
+			},
+			expectedNames: []string{"data", "data", "scratch"},
+		},
+		"region": {
+			region: "NORD-NO-KRS-1",
+			expectedIds: []string{
+				"00000000-0000-0000-0000-000000000001",
+				"00000000-0000-0000-0000-000000000003",
+			},
+			expectedNames: []string{"data", "scratch"},
+		},
+		"limit": {
+			limit: 2,
+			expectedIds: []string{
+				"00000000-0000-0000-0000-000000000001",
+				"00000000-0000-0000-0000-000000000002",
+			},
+			expectedNames: []string{"data", "data"},
+		},
+		"region and limit": {
+			region:        "NORD-NO-KRS-1",
+			limit:         1,
+			expectedIds:   []string{"00000000-0000-0000-0000-000000000001"},
+			expectedNames: []string{"data"},
+		},
+		"include resource": {
+			region:          "EUW-NL-AMS-1",
+			includeResource: true,
+			expectedIds:     []string{"00000000-0000-0000-0000-000000000002"},
+			expectedNames:   []string{"data"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			region := tftypes.NewValue(tftypes.String, nil)
+			if testCase.region != "" {
+				region = tftypes.NewValue(tftypes.String, testCase.region)
+			}
+
+			configType := configSchemaResp.Schema.Type().TerraformType(ctx)
+			req := list.ListRequest{
+				Config: tfsdk.Config{
+					Schema: configSchemaResp.Schema,
+					Raw:    tftypes.NewValue(configType, map[string]tftypes.Value{"region": region}),
+				},
+				IncludeResource:        testCase.includeResource,
+				Limit:                  testCase.limit,
+				ResourceSchema:         schemaResp.Schema,
+				ResourceIdentitySchema: identitySchemaResp.IdentitySchema,
+			}
+			stream := &list.ListResultsStream{}
+
+			r.List(ctx, req, stream)
+
+			var ids, names []string
+			for result := range stream.Results {
+				if result.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+				}
+
+				var id types.String
+				if diags := result.Identity.GetAttribute(ctx, path.Root("id"), &id); diags.HasError() {
+					t.Fatalf("unexpected identity diagnostics: %v", diags)
+				}
+				ids = append(ids, id.ValueString())
+				names = append(names, result.DisplayName)
+
+				var resourceName types.String
+				if diags := result.Resource.GetAttribute(ctx, path.Root("name"), &resourceName); diags.HasError() {
+					t.Fatalf("unexpected resource diagnostics: %v", diags)
+				}
+
+				if testCase.includeResource && resourceName.ValueString() != "read "+id.ValueString() {
+					t.Errorf("expected the resource to be read, got name %s", resourceName)
+				}
+				if !testCase.includeResource && !resourceName.IsNull() {
+					t.Errorf("expected no resource, got name %s", resourceName)
+				}
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(testCase.expectedIds) {
+				t.Errorf("expected ids %v, got %v", testCase.expectedIds, ids)
+			}
+			if fmt.Sprint(names) != fmt.Sprint(testCase.expectedNames) {
+				t.Errorf("expected names %v, got %v", testCase.expectedNames, names)
+			}
+		})
+	}
+}
+
+func TestResourceListResourceListError(t *testing.T) {
+	ctx := context.Background()
+
+	r := &ResourceListResource{
+		typeNameSuffix: "_ssh_key",
+		newResource:    func() fwresource.Resource { return &fakeListedResource{} },
+		list: func(ctx context.Context, client *Client) ([]importCandidate, diag.Diagnostics) {
+			var diags diag.Diagnostics
+			diags.AddError("Client Error", "unable to list")
+			return nil, diags
+		},
+	}
+
+	configSchemaResp := &list.ListResourceSchemaResponse{}
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, configSchemaResp)
+
+	req := list.ListRequest{
+		Config: tfsdk.Config{
+			Schema: configSchemaResp.Schema,
+			Raw:    tftypes.NewValue(configSchemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{}),
+		},
+	}
+	stream := &list.ListResultsStream{}
+
+	r.List(ctx, req, stream)
+
+	var results int
+	for result := range stream.Results {
+		results++
+		if !result.Diagnostics.HasError() {
+			t.Errorf("expected an error, got %v", result.Diagnostics)
+		}
+	}
+
+	if results != 1 {
+		t.Errorf("expected 1 result, got %d", results)
+	}
+}
+
+func TestResourceListResourceReadResource(t *testing.T) {
+	ctx := context.Background()
+
+	r := &ResourceListResource{
+		newResource: func() fwresource.Resource { return &fakeListedResource{} },
+	}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	(&fakeListedResource{}).Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	testCases := map[string]struct {
+		id           string
+		expectedName string
+		expectError  bool
+	}{
+		"read": {
+			id:           "00000000-0000-0000-0000-000000000001",
+			expectedName: "read 00000000-0000-0000-0000-000000000001",
+		},
+		"read error": {
+			id:          "missing",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			target := &tfsdk.Resource{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+
+			diags := r.readResource(ctx, testCase.id, target)
+
+			if diags.HasError() != testCase.expectError {
+				t.Fatalf("expected error %t, got %v", testCase.expectError, diags)
+			}
+
+			var id, resourceName types.String
+			diags.Append(target.GetAttribute(ctx, path.Root("id"), &id)...)
+			diags.Append(target.GetAttribute(ctx, path.Root("name"), &resourceName)...)
+
+			if testCase.expectError {
+				// The target is left untouched
+				if !target.Raw.IsNull() {
+					t.Errorf("expected the resource to be untouched, got %v", target.Raw)
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if id.ValueString() != testCase.id {
+				t.Errorf("expected id %s, got %s", testCase.id, id)
+			}
+			if resourceName.ValueString() != testCase.expectedName {
+				t.Errorf("expected name %s, got %s", testCase.expectedName, resourceName)
+			}
+		})
+	}
+}
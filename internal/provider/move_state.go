@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// genesisCloudProviderSource is the NAMESPACE/TYPE of the Genesis Cloud provider this provider was forked from.
+const genesisCloudProviderSource = "genesiscloud/genesiscloud"
+
+// genesisCloudStateMover returns a state mover accepting the state of the matching
+// Genesis Cloud resource type, e.g. genesiscloud_volume for sagadata_volume.
+//
+// Attributes unknown to the target schema are dropped and missing attributes are
+// set to null, so earlier schema shapes are accepted as well. Computed attributes
+// are populated again by the subsequent read. The renames map renamed attributes
+// from their earlier name to their current name.
+func genesisCloudStateMover(typeNameSuffix string, renames map[string]string) resource.StateMover {
+	return resource.StateMover{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			if !strings.HasSuffix(req.SourceProviderAddress, genesisCloudProviderSource) {
+				return
+			}
+
+			if req.SourceTypeName != "genesiscloud"+typeNameSuffix {
+				return
+			}
+
+			if req.SourceRawState == nil {
+				resp.Diagnostics.AddError("Move State Error", fmt.Sprintf("The source state of %s is missing.", req.SourceTypeName))
+				return
+			}
+
+			rawState, err := renameRawStateAttributes(req.SourceRawState, renames)
+			if err != nil {
+				resp.Diagnostics.AddError("Move State Error", generateErrorMessage(fmt.Sprintf("move state from %s", req.SourceTypeName), err))
+				return
+			}
+
+			targetType := resp.TargetState.Schema.Type().TerraformType(ctx)
+
+			value, err := rawState.UnmarshalWithOpts(targetType, tfprotov6.UnmarshalOpts{
+				ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+					IgnoreUndefinedAttributes: true,
+				},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Move State Error", generateErrorMessage(fmt.Sprintf("move state from %s", req.SourceTypeName), err))
+				return
+			}
+
+			resp.TargetState.Raw = value
+		},
+	}
+}
+
+// renameRawStateAttributes renames the top-level attributes of a raw JSON state.
+func renameRawStateAttributes(rawState *tfprotov6.RawState, renames map[string]string) (*tfprotov6.RawState, error) {
+	if len(renames) == 0 || rawState.JSON == nil {
+		return rawState, nil
+	}
+
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(rawState.JSON, &attributes); err != nil {
+		return nil, err
+	}
+
+	for from, to := range renames {
+		value, ok := attributes[from]
+		if !ok {
+			continue
+		}
+
+		delete(attributes, from)
+
+		if _, ok := attributes[to]; !ok {
+			attributes[to] = value
+		}
+	}
+
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.RawState{JSON: data}, nil
+}
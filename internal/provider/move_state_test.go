@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestGenesisCloudStateMover(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewSnapshotResource().(*SnapshotResource)
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	// An earlier schema shape with a renamed and a removed attribute
+	rawState := &tfprotov6.RawState{
+		JSON: []byte(`{
+			"id": "18efeec8-94f0-4776-8ff2-5e9b49c74608",
+			"name": "example",
+			"instance_id": "6b4b6d6e-3f0c-4d2b-9d0c-7b1f2e1b1e7a",
+			"region": "NORD-NO-KRS-1",
+			"removed_attribute": "value",
+			"timeouts": null
+		}`),
+	}
+
+	testCases := map[string]struct {
+		providerAddress string
+		typeName        string
+		expectMoved     bool
+	}{
+		"genesiscloud": {
+			providerAddress: "registry.terraform.io/genesiscloud/genesiscloud",
+			typeName:        "genesiscloud_snapshot",
+			expectMoved:     true,
+		},
+		"other resource type": {
+			providerAddress: "registry.terraform.io/genesiscloud/genesiscloud",
+			typeName:        "genesiscloud_volume",
+		},
+		"other provider": {
+			providerAddress: "registry.terraform.io/hashicorp/random",
+			typeName:        "genesiscloud_snapshot",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+
+			resp := &fwresource.MoveStateResponse{
+				TargetState: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaType, nil),
+				},
+			}
+
+			for _, mover := range r.MoveState(ctx) {
+				mover.StateMover(ctx, fwresource.MoveStateRequest{
+					SourceProviderAddress: testCase.providerAddress,
+					SourceTypeName:        testCase.typeName,
+					SourceRawState:        rawState,
+				}, resp)
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !testCase.expectMoved {
+				if !resp.TargetState.Raw.IsNull() {
+					t.Fatalf("expected state not to be moved")
+				}
+				return
+			}
+
+			var data SnapshotResourceModel
+			resp.Diagnostics.Append(resp.TargetState.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !data.Id.Equal(types.StringValue("18efeec8-94f0-4776-8ff2-5e9b49c74608")) {
+				t.Errorf("unexpected id: %s", data.Id)
+			}
+			if !data.SourceInstanceId.Equal(types.StringValue("6b4b6d6e-3f0c-4d2b-9d0c-7b1f2e1b1e7a")) {
+				t.Errorf("unexpected source_instance_id: %s", data.SourceInstanceId)
+			}
+			if !data.SourceVolumeId.IsNull() {
+				t.Errorf("expected source_volume_id to be null, got: %s", data.SourceVolumeId)
+			}
+		})
+	}
+}
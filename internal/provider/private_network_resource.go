@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -22,10 +23,22 @@ import (
 var (
 	_ resource.Resource                     = &PrivateNetworkResource{}
 	_ resource.ResourceWithConfigure        = &PrivateNetworkResource{}
+	_ resource.ResourceWithModifyPlan       = &PrivateNetworkResource{}
 	_ resource.ResourceWithImportState      = &PrivateNetworkResource{}
 	_ resource.ResourceWithConfigValidators = &PrivateNetworkResource{}
+	_ resource.ResourceWithMoveState        = &PrivateNetworkResource{}
+	_ resource.ResourceWithIdentity         = &PrivateNetworkResource{}
 )
 
+// privateNetworkErrorFieldAttributes maps the fields of the private network requests to their attributes.
+var privateNetworkErrorFieldAttributes = map[string]string{
+	"cidr_v4":     "cidr_v4",
+	"cidr_v6":     "cidr_v6",
+	"description": "description",
+	"name":        "name",
+	"region":      "region",
+}
+
 func NewPrivateNetworkResource() resource.Resource {
 	return &PrivateNetworkResource{}
 }
@@ -34,6 +47,7 @@ func NewPrivateNetworkResource() resource.Resource {
 type PrivateNetworkResource struct {
 	ResourceWithClient
 	ResourceWithTimeout
+	ResourceWithIdIdentity
 }
 
 func (r *PrivateNetworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -86,9 +100,11 @@ func (r *PrivateNetworkResource) Schema(ctx context.Context, req resource.Schema
 				Required:            true,
 			}),
 			"region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "The region identifier.",
-				Required:            true,
+				MarkdownDescription: "The region identifier. Defaults to the `default_region` of the provider.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -122,6 +138,10 @@ func (r *PrivateNetworkResource) ConfigValidators(ctx context.Context) []resourc
 	}
 }
 
+func (r *PrivateNetworkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanDefaultRegion(ctx, r.client, req, resp)
+}
+
 func (r *PrivateNetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data PrivateNetworkResourceModel
 
@@ -130,7 +150,7 @@ func (r *PrivateNetworkResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_private_network").Create)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -162,7 +182,7 @@ func (r *PrivateNetworkResource) Create(ctx context.Context, req resource.Create
 
 	networkResponse := response.JSON201
 	if networkResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("create private network", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("create private network", privateNetworkErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -177,6 +197,8 @@ func (r *PrivateNetworkResource) Create(ctx context.Context, req resource.Create
 
 	tflog.Trace(ctx, "created a private network resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -184,47 +206,68 @@ func (r *PrivateNetworkResource) Create(ctx context.Context, req resource.Create
 
 	networkId := networkResponse.PrivateNetwork.Id
 
+	network, diags := r.waitForCreated(ctx, networkId)
+	if diags.HasError() && isWaitTimeout(ctx) {
+		resp.Diagnostics.Append(markProvisioning(ctx, resp.Private, "private network")...)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, network)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if network.Status == sagadata.PrivateNetworkStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling private network", ErrResourceInErrorState))
+	}
+}
+
+// privateNetworkProvisioned returns whether the private network finished provisioning.
+func privateNetworkProvisioned(network *sagadata.PrivateNetwork) bool {
+	return network.Status == sagadata.PrivateNetworkStatusCreated || network.Status == sagadata.PrivateNetworkStatusError
+}
+
+// waitForCreated polls the private network until it finished provisioning.
+func (r *PrivateNetworkResource) waitForCreated(ctx context.Context, networkId string) (*sagadata.PrivateNetwork, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	for {
 		err := r.client.PollingWait(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling private network", err))
-			return
+			diags.AddError("Polling Error", generateErrorMessage("polling private network", err))
+			return nil, diags
 		}
 
 		tflog.Trace(ctx, "polling a private network resource")
 
 		response, err := r.client.GetPrivateNetworkWithResponse(ctx, networkId)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling private network", err))
-			return
+			diags.AddError("Client Error", generateErrorMessage("polling private network", err))
+			return nil, diags
 		}
 
 		networkResponse := response.JSON200
 		if networkResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling private network", ErrorResponse{
+			diags.Append(newClientErrorDiagnostic("polling private network", nil, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
 			}))
-			return
+			return nil, diags
 		}
 
-		status := networkResponse.PrivateNetwork.Status
-		if status == sagadata.PrivateNetworkStatusCreated || status == sagadata.PrivateNetworkStatusError {
-			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &networkResponse.PrivateNetwork)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			if status == sagadata.PrivateNetworkStatusError {
-				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling private network", ErrResourceInErrorState))
-			}
-			return
+		if privateNetworkProvisioned(&networkResponse.PrivateNetwork) {
+			return &networkResponse.PrivateNetwork, diags
 		}
 	}
 }
@@ -237,7 +280,7 @@ func (r *PrivateNetworkResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_private_network").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -246,7 +289,7 @@ func (r *PrivateNetworkResource) Read(ctx context.Context, req resource.ReadRequ
 
 	networkId := data.Id.ValueString()
 
-	response, err := r.client.GetPrivateNetworkWithResponse(ctx, networkId)
+	response, err := r.client.GetPrivateNetworkCachedWithResponse(ctx, networkId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read private network", err))
 		return
@@ -254,7 +297,7 @@ func (r *PrivateNetworkResource) Read(ctx context.Context, req resource.ReadRequ
 
 	networkResponse := response.JSON200
 	if networkResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read private network", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read private network", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -262,13 +305,21 @@ func (r *PrivateNetworkResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &networkResponse.PrivateNetwork)...)
+	network, diags := resumeProvisioning(ctx, resp.Private, "private network", networkId, &networkResponse.PrivateNetwork, privateNetworkProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, network)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Trace(ctx, "read a private network resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -280,13 +331,19 @@ func (r *PrivateNetworkResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update, r.client.DefaultTimeouts("sagadata_private_network").Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 	defer cancel()
 
+	_, diags := resumeProvisioning(ctx, resp.Private, "private network", data.Id.ValueString(), nil, privateNetworkProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	body := sagadata.UpdatePrivateNetworkJSONRequestBody{}
 
 	body.Name = pointer(data.Name.ValueString())
@@ -302,7 +359,7 @@ func (r *PrivateNetworkResource) Update(ctx context.Context, req resource.Update
 
 	networkResponse := response.JSON200
 	if networkResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("update private network", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("update private network", privateNetworkErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -328,7 +385,7 @@ func (r *PrivateNetworkResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete, r.client.DefaultTimeouts("sagadata_private_network").Delete)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -344,7 +401,7 @@ func (r *PrivateNetworkResource) Delete(ctx context.Context, req resource.Delete
 	}
 
 	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("delete private network", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("delete private network", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -374,5 +431,11 @@ func (r *PrivateNetworkResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *PrivateNetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp, path.Root("id"), "private network", r.client, listPrivateNetworkImportCandidates)
+}
+
+func (r *PrivateNetworkResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		genesisCloudStateMover("_private_network", nil),
+	}
 }
@@ -3,16 +3,27 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/sagadata-public/sagadata-go"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/providerenhancer"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/timedurationvalidator"
@@ -20,7 +31,9 @@ import (
 
 // Ensure SagaDataProvider satisfies various provider interfaces.
 var (
-	_ provider.Provider = &SagaDataProvider{}
+	_ provider.Provider                     = &SagaDataProvider{}
+	_ provider.ProviderWithListResources    = &SagaDataProvider{}
+	_ provider.ProviderWithConfigValidators = &SagaDataProvider{}
 )
 
 // SagaDataProvider defines the provider implementation.
@@ -33,9 +46,39 @@ type SagaDataProvider struct {
 
 // SagaDataProviderModel describes the provider data model.
 type SagaDataProviderModel struct {
-	Endpoint        types.String `tfsdk:"endpoint"`
-	Token           types.String `tfsdk:"token"`
-	PollingInterval types.String `tfsdk:"polling_interval"`
+	Endpoint         types.String `tfsdk:"endpoint"`
+	Token            types.String `tfsdk:"token"`
+	PollingInterval  types.String `tfsdk:"polling_interval"`
+	DefaultRegion    types.String `tfsdk:"default_region"`
+	DefaultTimeouts  types.Object `tfsdk:"default_timeouts"`
+	ResourceTimeouts types.Map    `tfsdk:"resource_timeouts"`
+	Profile          types.String `tfsdk:"profile"`
+	CredentialsFile  types.String `tfsdk:"credentials_file"`
+	TokenCommand     types.String `tfsdk:"token_command"`
+
+	SkipCredentialsValidation types.Bool `tfsdk:"skip_credentials_validation"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+
+	RequestsPerSecond      types.Int64 `tfsdk:"requests_per_second"`
+	Burst                  types.Int64 `tfsdk:"burst"`
+	MaxConcurrentMutations types.Int64 `tfsdk:"max_concurrent_mutations"`
+	ReadCache              types.Bool  `tfsdk:"read_cache"`
+}
+
+// SagaDataProviderTimeoutsModel describes the timeouts of the provider data model.
+type SagaDataProviderTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
 }
 
 func (p *SagaDataProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -44,6 +87,17 @@ func (p *SagaDataProvider) Metadata(ctx context.Context, req provider.MetadataRe
 }
 
 func (p *SagaDataProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	timeoutAttributes := map[string]schema.Attribute{}
+	for _, operation := range []string{"create", "read", "update", "delete"} {
+		timeoutAttributes[operation] = providerenhancer.Attribute(ctx, schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("The timeout of %s operations.", operation),
+			Optional:            true,
+			Validators: []validator.String{
+				timedurationvalidator.Positive(),
+			},
+		})
+	}
+
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "The Saga Data provider is used to interact with resources supported by [Saga Data](https://www.sagadata.no/). The provider needs to be configured with the proper credentials before it can be used.",
 		Attributes: map[string]schema.Attribute{
@@ -58,6 +112,87 @@ func (p *SagaDataProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "The profile of the credentials file to read the `endpoint`, `token`, `token_command` and `default_region` from. " +
+					"May also be provided via `SAGADATA_PROFILE` environment variable. If neither is provided, the `default` profile is used if it exists. " +
+					"Attributes and environment variables take precedence over the profile.",
+				Optional: true,
+			},
+			"credentials_file": schema.StringAttribute{
+				MarkdownDescription: "The path of the credentials file. May also be provided via `SAGADATA_CREDENTIALS_FILE` environment variable. " +
+					"If neither is provided, defaults to `~/.config/sagadata/credentials`.",
+				Optional: true,
+			},
+			"token_command": schema.StringAttribute{
+				MarkdownDescription: "A shell command which prints the Saga Data API token, e.g. of a password manager CLI. " +
+					"May also be provided via `SAGADATA_TOKEN_COMMAND` environment variable. It is only run when no `token` is provided and is killed when it does not finish within 30 seconds.",
+				Optional: true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				MarkdownDescription: "Skip the validation of the credentials with a request to the Saga Data API when the provider is configured, e.g. for offline plans. Defaults to `false`.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "The path of a PEM encoded CA certificate bundle to trust in addition to the system certificates, e.g. of a TLS-inspecting proxy. " +
+					"May also be provided via `SAGADATA_CA_CERT_FILE` environment variable.",
+				Optional: true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "A PEM encoded CA certificate bundle to trust in addition to the system certificates.",
+				Optional:            true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "The path of the PEM encoded client certificate for mutual TLS. Requires a client key.",
+				Optional:            true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded client certificate for mutual TLS. Requires a client key.",
+				Optional:            true,
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "The path of the PEM encoded private key of the client certificate.",
+				Optional:            true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded private key of the client certificate.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification of the TLS certificate of the Saga Data API. This makes the connection vulnerable to man-in-the-middle attacks and should only be used for debugging. Defaults to `false`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the HTTP proxy for all requests to the Saga Data API. May also be provided via `SAGADATA_PROXY_URL` environment variable. " +
+					"If neither is provided, the proxy of the `HTTPS_PROXY` and `NO_PROXY` environment variables is used.",
+				Optional: true,
+			},
+			"requests_per_second": providerenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: "The maximum sustained number of requests per second to the Saga Data API, shared by all resources. Unlimited if not set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			}),
+			"burst": providerenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: "The number of requests which may exceed `requests_per_second` at once. Defaults to `requests_per_second`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			}),
+			"max_concurrent_mutations": providerenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of concurrent create, update and delete requests to the Saga Data API. Unlimited if not set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			}),
+			"read_cache": schema.BoolAttribute{
+				MarkdownDescription: "Coalesce the reads of many resources of the same type into list requests during a refresh. " +
+					"Listed resources are used for a few seconds and until the next create, update or delete request. Defaults to `true`.",
+				Optional: true,
+			},
 			"polling_interval": providerenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The polling interval.",
 				Optional:            true,
@@ -65,10 +200,46 @@ func (p *SagaDataProvider) Schema(ctx context.Context, req provider.SchemaReques
 					timedurationvalidator.Positive(),
 				},
 			}),
+			"default_timeouts": schema.SingleNestedAttribute{
+				MarkdownDescription: "The timeouts of resources and data sources which do not set the `timeouts` attribute. Defaults to `20m` for every operation.",
+				Optional:            true,
+				Attributes:          timeoutAttributes,
+			},
+			"resource_timeouts": schema.MapNestedAttribute{
+				MarkdownDescription: "The timeouts by resource or data source type name, e.g. `sagadata_instance`, which take precedence over `default_timeouts`.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: timeoutAttributes,
+				},
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(p.typeNames(ctx)...)),
+				},
+			},
+			"default_region": providerenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The region of regional resources which do not set a region. May also be provided via `SAGADATA_REGION` environment variable. " +
+					"Changing the default region replaces the resources which use it.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sliceStringify(sagadata.AllRegions)...),
+				},
+			}),
 		},
 	}
 }
 
+func (p *SagaDataProvider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.Conflicting(
+			path.MatchRoot("client_cert_file"),
+			path.MatchRoot("client_cert_pem"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("client_key_file"),
+			path.MatchRoot("client_key_pem"),
+		),
+	}
+}
+
 func (p *SagaDataProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data SagaDataProviderModel
 
@@ -96,6 +267,70 @@ func (p *SagaDataProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	if data.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown Profile",
+			"The provider cannot create the Saga Data API client as there is an unknown configuration value for the Profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the SAGADATA_PROFILE environment variable.",
+		)
+	}
+
+	if data.CredentialsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unknown Credentials File",
+			"The provider cannot create the Saga Data API client as there is an unknown configuration value for the Credentials File. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the SAGADATA_CREDENTIALS_FILE environment variable.",
+		)
+	}
+
+	if data.TokenCommand.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_command"),
+			"Unknown Token Command",
+			"The provider cannot create the Saga Data API client as there is an unknown configuration value for the Token Command. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the SAGADATA_TOKEN_COMMAND environment variable.",
+		)
+	}
+
+	if data.SkipCredentialsValidation.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("skip_credentials_validation"),
+			"Unknown Skip Credentials Validation",
+			"The provider cannot create the Saga Data API client as there is an unknown configuration value for the Skip Credentials Validation. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or remove it to use the default.",
+		)
+	}
+
+	for _, clientAttribute := range []struct {
+		name  string
+		title string
+		value attr.Value
+	}{
+		{name: "ca_cert_file", title: "CA Certificate File", value: data.CACertFile},
+		{name: "ca_cert_pem", title: "CA Certificate", value: data.CACertPEM},
+		{name: "client_cert_file", title: "Client Certificate File", value: data.ClientCertFile},
+		{name: "client_cert_pem", title: "Client Certificate", value: data.ClientCertPEM},
+		{name: "client_key_file", title: "Client Key File", value: data.ClientKeyFile},
+		{name: "client_key_pem", title: "Client Key", value: data.ClientKeyPEM},
+		{name: "insecure_skip_verify", title: "Insecure Skip Verify", value: data.InsecureSkipVerify},
+		{name: "proxy_url", title: "Proxy URL", value: data.ProxyURL},
+		{name: "requests_per_second", title: "Requests Per Second", value: data.RequestsPerSecond},
+		{name: "burst", title: "Burst", value: data.Burst},
+		{name: "max_concurrent_mutations", title: "Max Concurrent Mutations", value: data.MaxConcurrentMutations},
+		{name: "read_cache", title: "Read Cache", value: data.ReadCache},
+	} {
+		if clientAttribute.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(clientAttribute.name),
+				"Unknown "+clientAttribute.title,
+				"The provider cannot create the Saga Data API client as there is an unknown configuration value for the "+clientAttribute.title+". "+
+					"Either target apply the source of the value first, set the value statically in the configuration, or remove it.",
+			)
+		}
+	}
+
 	if data.PollingInterval.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("polling_interval"),
@@ -105,13 +340,44 @@ func (p *SagaDataProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	if data.DefaultRegion.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_region"),
+			"Unknown Default Region",
+			"The provider cannot create the Saga Data API client as there is an unknown configuration value for the Default Region. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the SAGADATA_REGION environment variable.",
+		)
+	}
+
+	if data.DefaultTimeouts.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_timeouts"),
+			"Unknown Default Timeouts",
+			"The provider cannot create the Saga Data API client as there is an unknown configuration value for the Default Timeouts. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or remove it to use the default.",
+		)
+	}
+
+	if data.ResourceTimeouts.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resource_timeouts"),
+			"Unknown Resource Timeouts",
+			"The provider cannot create the Saga Data API client as there is an unknown configuration value for the Resource Timeouts. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or remove it to use the default.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	endpoint := os.Getenv("SAGADATA_ENDPOINT")
 	token := os.Getenv("SAGADATA_TOKEN")
+	tokenCommand := os.Getenv("SAGADATA_TOKEN_COMMAND")
+	profileName := os.Getenv("SAGADATA_PROFILE")
+	credentialsFile := os.Getenv("SAGADATA_CREDENTIALS_FILE")
 	pollingInterval := 2 * time.Second
+	defaultRegion := os.Getenv("SAGADATA_REGION")
 
 	if !data.Endpoint.IsNull() {
 		endpoint = data.Endpoint.ValueString()
@@ -119,6 +385,22 @@ func (p *SagaDataProvider) Configure(ctx context.Context, req provider.Configure
 	if !data.Token.IsNull() {
 		token = data.Token.ValueString()
 	}
+
+	// tokenSource Describes where the token comes from for diagnostics
+	tokenSource := "the token attribute"
+	if data.Token.IsNull() {
+		tokenSource = "the SAGADATA_TOKEN environment variable"
+	}
+
+	if !data.TokenCommand.IsNull() {
+		tokenCommand = data.TokenCommand.ValueString()
+	}
+	if !data.Profile.IsNull() {
+		profileName = data.Profile.ValueString()
+	}
+	if !data.CredentialsFile.IsNull() {
+		credentialsFile = data.CredentialsFile.ValueString()
+	}
 	if !data.PollingInterval.IsNull() {
 		duration, err := time.ParseDuration(data.PollingInterval.ValueString())
 		if err != nil {
@@ -132,6 +414,108 @@ func (p *SagaDataProvider) Configure(ctx context.Context, req provider.Configure
 
 		pollingInterval = duration
 	}
+	if !data.DefaultRegion.IsNull() {
+		defaultRegion = data.DefaultRegion.ValueString()
+	}
+
+	timeouts := ClientTimeouts{
+		Resources: map[string]Timeouts{},
+	}
+
+	if !data.DefaultTimeouts.IsNull() {
+		var timeoutsData SagaDataProviderTimeoutsModel
+
+		resp.Diagnostics.Append(data.DefaultTimeouts.As(ctx, &timeoutsData, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var diags diag.Diagnostics
+		timeouts.Default, diags = parseProviderTimeouts(path.Root("default_timeouts"), timeoutsData)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if !data.ResourceTimeouts.IsNull() {
+		var resourceTimeoutsData map[string]SagaDataProviderTimeoutsModel
+
+		resp.Diagnostics.Append(data.ResourceTimeouts.ElementsAs(ctx, &resourceTimeoutsData, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for typeName, timeoutsData := range resourceTimeoutsData {
+			resourceTimeouts, diags := parseProviderTimeouts(path.Root("resource_timeouts").AtMapKey(typeName), timeoutsData)
+			resp.Diagnostics.Append(diags...)
+
+			timeouts.Resources[typeName] = resourceTimeouts
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only an explicitly selected profile has to exist
+	profileRequired := profileName != ""
+	if profileName == "" {
+		profileName = defaultProfile
+	}
+
+	if credentialsFile == "" {
+		var err error
+		credentialsFile, err = defaultCredentialsFile()
+		if err != nil && profileRequired {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credentials_file"),
+				"Unable to Locate Credentials File",
+				"The provider cannot locate the credentials file of the profile. "+
+					"Set the credentials_file in the configuration or use the SAGADATA_CREDENTIALS_FILE environment variable.\n\n"+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	profile := &credentialsProfile{}
+	if credentialsFile != "" {
+		var err error
+		profile, err = readCredentialsProfile(credentialsFile, profileName, profileRequired)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("profile"),
+				"Unable to Read Credentials Profile",
+				fmt.Sprintf("The provider cannot read the profile %q of the credentials file.\n\nError: %s", profileName, err.Error()),
+			)
+			return
+		}
+	}
+
+	if endpoint == "" {
+		endpoint = profile.Endpoint
+	}
+	if token == "" && tokenCommand == "" {
+		token = profile.Token
+		tokenCommand = profile.TokenCommand
+		tokenSource = fmt.Sprintf("the profile %q of the credentials file", profileName)
+	}
+	if defaultRegion == "" {
+		defaultRegion = profile.DefaultRegion
+	}
+
+	if token == "" && tokenCommand != "" {
+		var err error
+		token, err = runTokenCommand(ctx, tokenCommand)
+		tokenSource = "the token command"
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_command"),
+				"Unable to Run Token Command",
+				"The provider cannot get the Saga Data API token from the token command.\n\n"+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
 
 	if endpoint == "" {
 		endpoint = sagadata.DefaultEndpoint
@@ -142,21 +526,64 @@ func (p *SagaDataProvider) Configure(ctx context.Context, req provider.Configure
 			path.Root("token"),
 			"Missing Saga Data API token",
 			"The provider cannot create the Saga Data API client as there is a missing or empty value for the Saga Data API token. "+
-				"Set the token value in the configuration or use the SAGADATA_TOKEN environment variable. "+
+				"Set the token value in the configuration, use the SAGADATA_TOKEN environment variable or set the token in the profile of the credentials file. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
+	if defaultRegion != "" && !slices.Contains(sliceStringify(sagadata.AllRegions), defaultRegion) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_region"),
+			"Invalid Default Region",
+			fmt.Sprintf("The default region %q is not a valid region. The value must be one of: %s", defaultRegion, strings.Join(sliceStringify(sagadata.AllRegions), ", ")),
+		)
+	}
+
+	transport, diags := providerTransportConfig(data)
+	resp.Diagnostics.Append(diags...)
+
+	if !data.Burst.IsNull() && data.RequestsPerSecond.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("burst"),
+			"Missing Requests Per Second",
+			"The burst only applies to a rate limit. Set requests_per_second as well or remove the burst.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if transport.InsecureSkipVerify {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"The TLS certificate of the Saga Data API is not verified. The connection, including the API token, "+
+				"is vulnerable to man-in-the-middle attacks. Only use insecure_skip_verify for debugging and "+
+				"prefer ca_cert_file or ca_cert_pem to trust a TLS-inspecting proxy.",
+		)
+	}
+
+	readCacheTTL := defaultReadCacheTTL
+	if !data.ReadCache.IsNull() && !data.ReadCache.ValueBool() {
+		readCacheTTL = 0
+	}
+
 	providerClient, err := NewClient(ctx, ClientConfig{
 		ClientConfig: sagadata.ClientConfig{
 			Endpoint: endpoint,
 			Token:    token,
 		},
 		PollingInterval: pollingInterval,
+		DefaultRegion:   defaultRegion,
+		Timeouts:        timeouts,
+		Transport:       transport,
+		RateLimit: RateLimitConfig{
+			RequestsPerSecond:      int(data.RequestsPerSecond.ValueInt64()),
+			Burst:                  int(data.Burst.ValueInt64()),
+			MaxConcurrentMutations: int(data.MaxConcurrentMutations.ValueInt64()),
+		},
+		ReadCacheTTL: readCacheTTL,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -168,14 +595,23 @@ func (p *SagaDataProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	if !data.SkipCredentialsValidation.ValueBool() {
+		resp.Diagnostics.Append(validateCredentials(ctx, providerClient, tokenSource)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	resp.DataSourceData = providerClient
 	resp.ResourceData = providerClient
+	resp.ListResourceData = providerClient
 }
 
 func (p *SagaDataProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewInstanceResource,
 		NewInstanceStatusResource,
+		NewInstanceActionResource,
 		NewSSHKeyResource,
 		NewFloatingIPResource,
 		NewVolumeResource,
@@ -191,7 +627,152 @@ func (p *SagaDataProvider) DataSources(ctx context.Context) []func() datasource.
 	return []func() datasource.DataSource{
 		NewImagesDataSource,
 		NewKubernetesClusterDataSource,
+		NewSnapshotsDataSource,
+	}
+}
+
+func (p *SagaDataProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewInstanceListResource,
+		NewVolumeListResource,
+		NewFilesystemListResource,
+		NewSecurityGroupListResource,
+		NewSnapshotListResource,
+		NewSSHKeyListResource,
+		NewFloatingIPListResource,
+		NewPrivateNetworkListResource,
+		NewKubernetesClusterListResource,
+	}
+}
+
+// typeNames returns the type names of the resources and data sources.
+func (p *SagaDataProvider) typeNames(ctx context.Context) []string {
+	var typeNames []string
+
+	for _, newResource := range p.Resources(ctx) {
+		metadataResp := &resource.MetadataResponse{}
+		newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "sagadata"}, metadataResp)
+		typeNames = append(typeNames, metadataResp.TypeName)
+	}
+
+	for _, newDataSource := range p.DataSources(ctx) {
+		metadataResp := &datasource.MetadataResponse{}
+		newDataSource().Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "sagadata"}, metadataResp)
+		typeNames = append(typeNames, metadataResp.TypeName)
+	}
+
+	slices.Sort(typeNames)
+
+	return slices.Compact(typeNames)
+}
+
+// providerTransportConfig reads the certificates and the proxy of the provider configuration.
+func providerTransportConfig(data SagaDataProviderModel) (config TransportConfig, diags diag.Diagnostics) {
+	readPEM := func(fileAttribute string, file string, pem types.String) []byte {
+		if !pem.IsNull() {
+			return []byte(pem.ValueString())
+		}
+		if file == "" {
+			return nil
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(fileAttribute),
+				"Unable to Read File",
+				fmt.Sprintf("The provider cannot read the file %q.\n\nError: %s", file, err.Error()),
+			)
+		}
+		return content
+	}
+
+	caCertFile := os.Getenv("SAGADATA_CA_CERT_FILE")
+	if !data.CACertFile.IsNull() {
+		caCertFile = data.CACertFile.ValueString()
+	}
+
+	// The CA certificates of the file and the attribute are combined
+	config.CACertPEM = readPEM("ca_cert_file", caCertFile, types.StringNull())
+	if !data.CACertPEM.IsNull() {
+		config.CACertPEM = append(append(config.CACertPEM, '\n'), data.CACertPEM.ValueString()...)
 	}
+
+	config.ClientCertPEM = readPEM("client_cert_file", data.ClientCertFile.ValueString(), data.ClientCertPEM)
+	config.ClientKeyPEM = readPEM("client_key_file", data.ClientKeyFile.ValueString(), data.ClientKeyPEM)
+
+	if (len(config.ClientCertPEM) == 0) != (len(config.ClientKeyPEM) == 0) {
+		diags.AddAttributeError(
+			path.Root("client_cert_pem"),
+			"Incomplete Client Certificate",
+			"Mutual TLS requires both a client certificate and a client key. "+
+				"Set client_cert_file or client_cert_pem together with client_key_file or client_key_pem.",
+		)
+	}
+
+	config.InsecureSkipVerify = data.InsecureSkipVerify.ValueBool()
+
+	proxyURL := os.Getenv("SAGADATA_PROXY_URL")
+	if !data.ProxyURL.IsNull() {
+		proxyURL = data.ProxyURL.ValueString()
+	}
+
+	if proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("The proxy URL %q is not a valid absolute URL, e.g. \"http://proxy.example.com:3128\".", proxyURL),
+			)
+		} else {
+			config.ProxyURL = parsed
+		}
+	}
+
+	return
+}
+
+// parseProviderTimeouts parses the configured timeouts, unset timeouts are zero.
+func parseProviderTimeouts(attrPath path.Path, data SagaDataProviderTimeoutsModel) (timeouts Timeouts, diags diag.Diagnostics) {
+	for _, timeout := range []struct {
+		name   string
+		value  types.String
+		target *time.Duration
+	}{
+		{name: "create", value: data.Create, target: &timeouts.Create},
+		{name: "read", value: data.Read, target: &timeouts.Read},
+		{name: "update", value: data.Update, target: &timeouts.Update},
+		{name: "delete", value: data.Delete, target: &timeouts.Delete},
+	} {
+		if timeout.value.IsUnknown() {
+			diags.AddAttributeError(
+				attrPath.AtName(timeout.name),
+				"Unknown Timeout",
+				"The provider cannot create the Saga Data API client as there is an unknown configuration value for the Timeout. "+
+					"Either target apply the source of the value first, set the value statically in the configuration, or remove it to use the default.",
+			)
+			continue
+		}
+
+		if timeout.value.IsNull() {
+			continue
+		}
+
+		duration, err := time.ParseDuration(timeout.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				attrPath.AtName(timeout.name),
+				"Timeout Cannot Be Parsed",
+				err.Error(),
+			)
+			continue
+		}
+
+		*timeout.target = duration
+	}
+
+	return
 }
 
 func New(version string) func() provider.Provider {
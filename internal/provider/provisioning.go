@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// provisioningKey is the key of the private state which marks a resource whose
+// create timed out while waiting for it to be provisioned.
+const provisioningKey = "provisioning"
+
+// privateState is the private state of a resource in requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// isWaitTimeout returns whether waiting was stopped by the timeout of the
+// operation rather than by a failure or cancellation.
+func isWaitTimeout(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// markProvisioning marks a created resource as still provisioning after the
+// create timeout expired. The resource is not tainted, as there are only
+// warnings, and the next read or update resumes waiting for it.
+func markProvisioning(ctx context.Context, private privateState, kind string) diag.Diagnostics {
+	diags := private.SetKey(ctx, provisioningKey, []byte("true"))
+
+	diags.AddWarning(
+		"Still Provisioning",
+		fmt.Sprintf("The %s was created but did not finish provisioning before the create timeout expired. ", kind)+
+			"It is kept in the state and the next refresh or apply resumes waiting for it. "+
+			"Increase the create timeout to wait longer.",
+	)
+
+	return diags
+}
+
+// resumeProvisioning resumes waiting for a resource which is marked as still
+// provisioning and removes the mark once it is provisioned. It returns the
+// provisioned resource, or current when the resource is not marked or still
+// provisioning after the timeout, which is a warning. Without a current
+// resource, as in updates, the timeout is an error.
+func resumeProvisioning[T any](ctx context.Context, private privateState, kind string, id string, current *T, provisioned func(*T) bool, wait func(ctx context.Context, id string) (*T, diag.Diagnostics)) (*T, diag.Diagnostics) {
+	marker, diags := private.GetKey(ctx, provisioningKey)
+	if diags.HasError() || marker == nil {
+		return current, diags
+	}
+
+	if current == nil || !provisioned(current) {
+		tflog.Info(ctx, "resuming to wait for a provisioning resource", map[string]any{"kind": kind, "id": id})
+
+		resource, waitDiags := wait(ctx, id)
+		if waitDiags.HasError() {
+			if !isWaitTimeout(ctx) {
+				diags.Append(waitDiags...)
+				return nil, diags
+			}
+
+			summary := "Still Provisioning"
+			detail := fmt.Sprintf("The %s did not finish provisioning before the timeout expired. ", kind) +
+				"The next refresh or apply resumes waiting for it."
+
+			if current == nil {
+				diags.AddError(summary, detail)
+			} else {
+				diags.AddWarning(summary, detail)
+			}
+			return current, diags
+		}
+
+		current = resource
+	}
+
+	diags.Append(private.SetKey(ctx, provisioningKey, nil)...)
+
+	return current, diags
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// testPrivateState is an in-memory private state.
+type testPrivateState map[string][]byte
+
+func (s testPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return s[key], nil
+}
+
+func (s testPrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(s, key)
+	} else {
+		s[key] = value
+	}
+	return nil
+}
+
+type testProvisioningResource struct {
+	Status string
+}
+
+func testProvisioningResourceProvisioned(resource *testProvisioningResource) bool {
+	return resource.Status == "created"
+}
+
+func TestMarkProvisioning(t *testing.T) {
+	private := testPrivateState{}
+
+	diags := markProvisioning(context.Background(), private, "volume")
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+
+	if _, ok := private[provisioningKey]; !ok {
+		t.Error("expected the resource to be marked as provisioning")
+	}
+}
+
+func TestResumeProvisioning(t *testing.T) {
+	ctx := context.Background()
+
+	waitCreated := func(ctx context.Context, id string) (*testProvisioningResource, diag.Diagnostics) {
+		return &testProvisioningResource{Status: "created"}, nil
+	}
+
+	waitTimeout := func(ctx context.Context, id string) (*testProvisioningResource, diag.Diagnostics) {
+		<-ctx.Done()
+
+		var diags diag.Diagnostics
+		diags.AddError("Polling Error", ctx.Err().Error())
+		return nil, diags
+	}
+
+	t.Run("not marked", func(t *testing.T) {
+		current := &testProvisioningResource{Status: "creating"}
+
+		resource, diags := resumeProvisioning(ctx, testPrivateState{}, "volume", "id", current, testProvisioningResourceProvisioned, waitTimeout)
+		if diags.HasError() || resource != current {
+			t.Errorf("expected the current resource without waiting, got %v: %v", resource, diags)
+		}
+	})
+
+	t.Run("provisioned", func(t *testing.T) {
+		private := testPrivateState{provisioningKey: []byte("true")}
+
+		resource, diags := resumeProvisioning(ctx, private, "volume", "id", &testProvisioningResource{Status: "creating"}, testProvisioningResourceProvisioned, waitCreated)
+		if diags.HasError() || resource.Status != "created" {
+			t.Errorf("expected the provisioned resource, got %v: %v", resource, diags)
+		}
+
+		if _, ok := private[provisioningKey]; ok {
+			t.Error("expected the mark to be removed")
+		}
+	})
+
+	t.Run("provisioned outside of a wait", func(t *testing.T) {
+		private := testPrivateState{provisioningKey: []byte("true")}
+
+		resource, diags := resumeProvisioning(ctx, private, "volume", "id", &testProvisioningResource{Status: "created"}, testProvisioningResourceProvisioned, waitTimeout)
+		if diags.HasError() || resource.Status != "created" {
+			t.Errorf("expected the current resource, got %v: %v", resource, diags)
+		}
+
+		if _, ok := private[provisioningKey]; ok {
+			t.Error("expected the mark to be removed")
+		}
+	})
+
+	t.Run("timeout on read", func(t *testing.T) {
+		private := testPrivateState{provisioningKey: []byte("true")}
+		current := &testProvisioningResource{Status: "creating"}
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		resource, diags := resumeProvisioning(ctx, private, "volume", "id", current, testProvisioningResourceProvisioned, waitTimeout)
+		if diags.HasError() || diags.WarningsCount() != 1 || resource != current {
+			t.Errorf("expected the current resource with a warning, got %v: %v", resource, diags)
+		}
+
+		if _, ok := private[provisioningKey]; !ok {
+			t.Error("expected the mark to be kept")
+		}
+	})
+
+	t.Run("timeout on update", func(t *testing.T) {
+		private := testPrivateState{provisioningKey: []byte("true")}
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, diags := resumeProvisioning(ctx, private, "volume", "id", nil, testProvisioningResourceProvisioned, waitTimeout)
+		if !diags.HasError() {
+			t.Error("expected an error")
+		}
+
+		if _, ok := private[provisioningKey]; !ok {
+			t.Error("expected the mark to be kept")
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimitConfig limits the requests to the API. Zero values are unlimited.
+type RateLimitConfig struct {
+	// RequestsPerSecond The sustained rate of requests.
+	RequestsPerSecond int
+
+	// Burst The number of requests which can exceed the rate at once.
+	Burst int
+
+	// MaxConcurrentMutations The number of concurrent create, update and delete requests.
+	MaxConcurrentMutations int
+}
+
+// rateLimiter is a token bucket which is refilled with rate tokens per second
+// up to burst tokens.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(requestsPerSecond int, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = requestsPerSecond
+	}
+
+	return &rateLimiter{
+		rate:   float64(requestsPerSecond),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	// Reserve the token, a negative balance is the time to wait for it
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// Return the reserved token for other requests
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitTransport limits the requests of the underlying transport. It is
+// placed below the retry client so retries are limited as well.
+type rateLimitTransport struct {
+	next http.RoundTripper
+
+	// limiter Limits all requests, nil is unlimited.
+	limiter *rateLimiter
+
+	// mutations Holds a slot for each in-flight mutating request, nil is unlimited.
+	mutations chan struct{}
+}
+
+func newRateLimitTransport(next http.RoundTripper, config RateLimitConfig) http.RoundTripper {
+	if config.RequestsPerSecond < 1 && config.MaxConcurrentMutations < 1 {
+		return next
+	}
+
+	transport := &rateLimitTransport{next: next}
+
+	if config.RequestsPerSecond > 0 {
+		transport.limiter = newRateLimiter(config.RequestsPerSecond, config.Burst)
+	}
+	if config.MaxConcurrentMutations > 0 {
+		transport.mutations = make(chan struct{}, config.MaxConcurrentMutations)
+	}
+
+	return transport
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.mutations != nil && isMutatingMethod(req.Method) {
+		select {
+		case t.mutations <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		defer func() { <-t.mutations }()
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
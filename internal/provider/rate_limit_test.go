@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(20, 5)
+
+	ctx := context.Background()
+	start := time.Now()
+
+	// The burst is available immediately, the remaining requests are spread at the rate
+	for i := 0; i < 10; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected 10 requests to take at least 200ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterContextDone(t *testing.T) {
+	limiter := newRateLimiter(1, 1)
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRateLimitTransportMutations(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			previous := maxInFlight.Load()
+			if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	transport := newRateLimitTransport(next, RateLimitConfig{MaxConcurrentMutations: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequest(http.MethodPost, "https://api.sagadata.no/compute/v1/instances", nil)
+			if _, err := transport.RoundTrip(req); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if actual := maxInFlight.Load(); actual != 2 {
+		t.Errorf("expected at most 2 concurrent mutations, got %d", actual)
+	}
+}
+
+func TestRateLimitTransportUnlimited(t *testing.T) {
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	if transport := newRateLimitTransport(next, RateLimitConfig{}); transport == nil {
+		t.Fatal("expected a transport")
+	} else if _, ok := transport.(*rateLimitTransport); ok {
+		t.Errorf("expected the transport not to be wrapped without limits")
+	}
+}
@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// defaultReadCacheTTL is how long listed resources are used for reads.
+const defaultReadCacheTTL = 10 * time.Second
+
+// readCache coalesces the reads of resources of the same kind into a single
+// list call whose result is shared for a short time. The first read of a kind
+// gets the resource directly, so a single resource does not list the whole
+// account. Any mutating request invalidates the cache.
+type readCache struct {
+	ttl time.Duration
+
+	mu         sync.Mutex
+	generation uint64
+	kinds      map[string]*readCacheKind
+}
+
+type readCacheKind struct {
+	reads int
+
+	// fetch The latest or in-flight list call.
+	fetch *readCacheFetch
+}
+
+type readCacheFetch struct {
+	done       chan struct{}
+	generation uint64
+
+	// fetchedAt Zero while the list call is in flight.
+	fetchedAt time.Time
+	items     map[string]any
+	ok        bool
+}
+
+// newReadCache returns a read cache, or nil which disables caching when the
+// ttl is not positive.
+func newReadCache(ttl time.Duration) *readCache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &readCache{
+		ttl:   ttl,
+		kinds: map[string]*readCacheKind{},
+	}
+}
+
+// get returns the item with the id from the list of the kind. It returns false
+// when the item has to be read directly: for the first read of a kind, when the
+// list call fails or when the item is not listed.
+func (c *readCache) get(ctx context.Context, kind string, id string, list func(ctx context.Context) (map[string]any, bool)) (any, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+
+	k, ok := c.kinds[kind]
+	if !ok {
+		k = &readCacheKind{}
+		c.kinds[kind] = k
+	}
+
+	k.reads++
+	if k.reads == 1 {
+		c.mu.Unlock()
+		return nil, false
+	}
+
+	fetch := k.fetch
+	leader := fetch == nil || fetch.generation != c.generation ||
+		(!fetch.fetchedAt.IsZero() && time.Since(fetch.fetchedAt) > c.ttl)
+	if leader {
+		fetch = &readCacheFetch{
+			done:       make(chan struct{}),
+			generation: c.generation,
+		}
+		k.fetch = fetch
+	}
+
+	c.mu.Unlock()
+
+	if leader {
+		items, ok := list(ctx)
+
+		c.mu.Lock()
+		fetch.items, fetch.ok = items, ok
+		fetch.fetchedAt = time.Now()
+		if !ok && k.fetch == fetch {
+			k.fetch = nil
+		}
+		c.mu.Unlock()
+
+		close(fetch.done)
+	}
+
+	select {
+	case <-fetch.done:
+	case <-ctx.Done():
+		return nil, false
+	}
+
+	if !fetch.ok {
+		return nil, false
+	}
+
+	item, ok := fetch.items[id]
+	return item, ok
+}
+
+// invalidate drops the listed resources of all kinds.
+func (c *readCache) invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for _, k := range c.kinds {
+		k.fetch = nil
+	}
+}
+
+// readCacheTransport invalidates the read cache before and after mutating requests.
+type readCacheTransport struct {
+	next  http.RoundTripper
+	cache *readCache
+}
+
+func (t *readCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isMutatingMethod(req.Method) {
+		return t.next.RoundTrip(req)
+	}
+
+	t.cache.invalidate()
+	defer t.cache.invalidate()
+
+	return t.next.RoundTrip(req)
+}
+
+// readCached returns the resource with the id from the read cache.
+func readCached[T any](ctx context.Context, client *Client, kind string, id string, list func(ctx context.Context, client *Client) ([]T, diag.Diagnostics), idOf func(T) string) (T, bool) {
+	item, ok := client.readCache.get(ctx, kind, id, func(ctx context.Context) (map[string]any, bool) {
+		resources, diags := list(ctx, client)
+		if diags.HasError() {
+			return nil, false
+		}
+
+		items := make(map[string]any, len(resources))
+		for _, resource := range resources {
+			items[idOf(resource)] = resource
+		}
+
+		return items, true
+	})
+	if !ok {
+		var zero T
+		return zero, false
+	}
+
+	return item.(T), true
+}
+
+// cachedHTTPResponse is the HTTP response of reads served by the read cache.
+func cachedHTTPResponse() *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+	}
+}
+
+// GetInstanceCachedWithResponse gets an instance through the read cache.
+func (c *Client) GetInstanceCachedWithResponse(ctx context.Context, instanceId string) (*sagadata.GetInstanceResponse, error) {
+	instance, ok := readCached(ctx, c, "instance", instanceId, listInstances, func(instance sagadata.Instance) string { return instance.Id })
+	if !ok {
+		return c.GetInstanceWithResponse(ctx, instanceId)
+	}
+
+	return &sagadata.GetInstanceResponse{
+		HTTPResponse: cachedHTTPResponse(),
+		JSON200: &struct {
+			Instance sagadata.Instance `json:"instance"`
+		}{Instance: instance},
+	}, nil
+}
+
+// GetVolumeCachedWithResponse gets a volume through the read cache.
+func (c *Client) GetVolumeCachedWithResponse(ctx context.Context, volumeId string) (*sagadata.GetVolumeResponse, error) {
+	volume, ok := readCached(ctx, c, "volume", volumeId, listVolumes, func(volume sagadata.Volume) string { return volume.Id })
+	if !ok {
+		return c.GetVolumeWithResponse(ctx, volumeId)
+	}
+
+	return &sagadata.GetVolumeResponse{
+		HTTPResponse: cachedHTTPResponse(),
+		JSON200: &struct {
+			Volume sagadata.Volume `json:"volume"`
+		}{Volume: volume},
+	}, nil
+}
+
+// GetFilesystemCachedWithResponse gets a filesystem through the read cache.
+func (c *Client) GetFilesystemCachedWithResponse(ctx context.Context, filesystemId string) (*sagadata.GetFilesystemResponse, error) {
+	filesystem, ok := readCached(ctx, c, "filesystem", filesystemId, listFilesystems, func(filesystem sagadata.Filesystem) string { return filesystem.Id })
+	if !ok {
+		return c.GetFilesystemWithResponse(ctx, filesystemId)
+	}
+
+	return &sagadata.GetFilesystemResponse{
+		HTTPResponse: cachedHTTPResponse(),
+		JSON200: &struct {
+			Filesystem sagadata.Filesystem `json:"filesystem"`
+		}{Filesystem: filesystem},
+	}, nil
+}
+
+// GetSecurityGroupCachedWithResponse gets a security group through the read cache.
+func (c *Client) GetSecurityGroupCachedWithResponse(ctx context.Context, securityGroupId string) (*sagadata.GetSecurityGroupResponse, error) {
+	securityGroup, ok := readCached(ctx, c, "security_group", securityGroupId, listSecurityGroups, func(securityGroup sagadata.SecurityGroup) string { return securityGroup.Id })
+	if !ok {
+		return c.GetSecurityGroupWithResponse(ctx, securityGroupId)
+	}
+
+	return &sagadata.GetSecurityGroupResponse{
+		HTTPResponse: cachedHTTPResponse(),
+		JSON200: &struct {
+			SecurityGroup sagadata.SecurityGroup `json:"securitygroup"`
+		}{SecurityGroup: securityGroup},
+	}, nil
+}
+
+// GetSnapshotCachedWithResponse gets a snapshot through the read cache.
+func (c *Client) GetSnapshotCachedWithResponse(ctx context.Context, snapshotId string) (*sagadata.GetSnapshotResponse, error) {
+	snapshot, ok := readCached(ctx, c, "snapshot", snapshotId, listSnapshots, func(snapshot sagadata.Snapshot) string { return snapshot.Id })
+	if !ok {
+		return c.GetSnapshotWithResponse(ctx, snapshotId)
+	}
+
+	return &sagadata.GetSnapshotResponse{
+		HTTPResponse: cachedHTTPResponse(),
+		JSON200:      &sagadata.SingleSnapshotResponse{Snapshot: snapshot},
+	}, nil
+}
+
+// GetSSHKeyCachedWithResponse gets an SSH key through the read cache.
+func (c *Client) GetSSHKeyCachedWithResponse(ctx context.Context, sshKeyId string) (*sagadata.GetSSHKeyResponse, error) {
+	sshKey, ok := readCached(ctx, c, "ssh_key", sshKeyId, listSSHKeys, func(sshKey sagadata.SSHKey) string { return sshKey.Id })
+	if !ok {
+		return c.GetSSHKeyWithResponse(ctx, sshKeyId)
+	}
+
+	return &sagadata.GetSSHKeyResponse{
+		HTTPResponse: cachedHTTPResponse(),
+		JSON200:      &sshKey,
+	}, nil
+}
+
+// GetFloatingIPCachedWithResponse gets a floating IP through the read cache.
+func (c *Client) GetFloatingIPCachedWithResponse(ctx context.Context, floatingIPId string) (*sagadata.GetFloatingIPResponse, error) {
+	floatingIP, ok := readCached(ctx, c, "floating_ip", floatingIPId, listFloatingIPs, func(floatingIP sagadata.FloatingIP) string { return floatingIP.Id })
+	if !ok {
+		return c.GetFloatingIPWithResponse(ctx, floatingIPId)
+	}
+
+	return &sagadata.GetFloatingIPResponse{
+		HTTPResponse: cachedHTTPResponse(),
+		JSON200: &struct {
+			FloatingIp sagadata.FloatingIP `json:"floatingip"`
+		}{FloatingIp: floatingIP},
+	}, nil
+}
+
+// GetPrivateNetworkCachedWithResponse gets a private network through the read cache.
+func (c *Client) GetPrivateNetworkCachedWithResponse(ctx context.Context, privateNetworkId string) (*sagadata.GetPrivateNetworkResponse, error) {
+	privateNetwork, ok := readCached(ctx, c, "private_network", privateNetworkId, listPrivateNetworks, func(privateNetwork sagadata.PrivateNetwork) string { return privateNetwork.Id })
+	if !ok {
+		return c.GetPrivateNetworkWithResponse(ctx, privateNetworkId)
+	}
+
+	return &sagadata.GetPrivateNetworkResponse{
+		HTTPResponse: cachedHTTPResponse(),
+		JSON200: &struct {
+			PrivateNetwork sagadata.PrivateNetwork `json:"privatenetwork"`
+		}{PrivateNetwork: privateNetwork},
+	}, nil
+}
+
+// GetKubernetesClusterCachedWithResponse gets a Kubernetes cluster through the read cache.
+func (c *Client) GetKubernetesClusterCachedWithResponse(ctx context.Context, clusterId string) (*sagadata.GetKubernetesClusterResponse, error) {
+	cluster, ok := readCached(ctx, c, "kubernetes_cluster", clusterId, listKubernetesClusters, func(cluster sagadata.KubernetesCluster) string { return cluster.Id })
+	if !ok {
+		return c.GetKubernetesClusterWithResponse(ctx, clusterId)
+	}
+
+	return &sagadata.GetKubernetesClusterResponse{
+		HTTPResponse: cachedHTTPResponse(),
+		JSON200: &struct {
+			Cluster sagadata.KubernetesCluster `json:"cluster"`
+		}{Cluster: cluster},
+	}, nil
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadCache(t *testing.T) {
+	ctx := context.Background()
+
+	var lists atomic.Int32
+	list := func(ctx context.Context) (map[string]any, bool) {
+		lists.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return map[string]any{"a": "volume a", "b": "volume b"}, true
+	}
+
+	cache := newReadCache(time.Minute)
+
+	// The first read of a kind is not cached
+	if _, ok := cache.get(ctx, "volume", "a", list); ok {
+		t.Errorf("expected the first read not to be cached")
+	}
+
+	// Concurrent reads share a single list call
+	var wg sync.WaitGroup
+	for _, id := range []string{"a", "b", "a", "b", "a"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if item, ok := cache.get(ctx, "volume", id, list); !ok || item != "volume "+id {
+				t.Errorf("expected %q to be cached, got %v", id, item)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if actual := lists.Load(); actual != 1 {
+		t.Errorf("expected 1 list call, got %d", actual)
+	}
+
+	// Unlisted resources are read directly
+	if _, ok := cache.get(ctx, "volume", "c", list); ok {
+		t.Errorf("expected an unlisted resource not to be cached")
+	}
+
+	// Invalidation lists again
+	cache.invalidate()
+	if _, ok := cache.get(ctx, "volume", "a", list); !ok {
+		t.Errorf("expected the resource to be cached")
+	}
+
+	if actual := lists.Load(); actual != 2 {
+		t.Errorf("expected 2 list calls, got %d", actual)
+	}
+}
+
+func TestReadCacheExpired(t *testing.T) {
+	ctx := context.Background()
+
+	var lists atomic.Int32
+	list := func(ctx context.Context) (map[string]any, bool) {
+		lists.Add(1)
+		return map[string]any{"a": "volume a"}, true
+	}
+
+	cache := newReadCache(time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		cache.get(ctx, "volume", "a", list)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if actual := lists.Load(); actual != 2 {
+		t.Errorf("expected 2 list calls, got %d", actual)
+	}
+}
+
+func TestReadCacheListError(t *testing.T) {
+	ctx := context.Background()
+
+	list := func(ctx context.Context) (map[string]any, bool) {
+		return nil, false
+	}
+
+	cache := newReadCache(time.Minute)
+	cache.get(ctx, "volume", "a", list)
+
+	if _, ok := cache.get(ctx, "volume", "a", list); ok {
+		t.Errorf("expected a failed list call not to be cached")
+	}
+}
+
+func TestReadCacheDisabled(t *testing.T) {
+	cache := newReadCache(0)
+
+	list := func(ctx context.Context) (map[string]any, bool) {
+		t.Fatal("unexpected list call")
+		return nil, false
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, ok := cache.get(context.Background(), "volume", "a", list); ok {
+			t.Errorf("expected a disabled cache not to cache")
+		}
+	}
+
+	cache.invalidate()
+}
+
+func TestReadCacheTransport(t *testing.T) {
+	ctx := context.Background()
+
+	var lists atomic.Int32
+	list := func(ctx context.Context) (map[string]any, bool) {
+		lists.Add(1)
+		return map[string]any{"a": "volume a"}, true
+	}
+
+	cache := newReadCache(time.Minute)
+	transport := &readCacheTransport{
+		next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}),
+		cache: cache,
+	}
+
+	cache.get(ctx, "volume", "a", list)
+	cache.get(ctx, "volume", "a", list)
+
+	for _, method := range []string{http.MethodGet, http.MethodPatch} {
+		req, _ := http.NewRequest(method, "https://api.sagadata.no/compute/v1/volumes/a", nil)
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+
+		cache.get(ctx, "volume", "a", list)
+	}
+
+	// Only the mutating request invalidates the cache
+	if actual := lists.Load(); actual != 2 {
+		t.Errorf("expected 2 list calls, got %d", actual)
+	}
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// modifyPlanDefaultRegion sets the region of the plan to the default region of
+// the provider when the region is not configured. A change of the effective
+// region requires the replacement of the resource.
+func modifyPlanDefaultRegion(ctx context.Context, client *Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configRegion types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("region"), &configRegion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !configRegion.IsNull() {
+		return
+	}
+
+	// The provider configuration is not known yet
+	if client == nil {
+		return
+	}
+
+	if client.DefaultRegion == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region"),
+			"Missing Region",
+			"The region is not set. Set the region in the resource configuration, "+
+				"the default_region in the provider configuration or use the SAGADATA_REGION environment variable.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("region"), client.DefaultRegion)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var stateRegion types.String
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("region"), &stateRegion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if stateRegion.ValueString() != client.DefaultRegion {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("region"))
+	}
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestModifyPlanDefaultRegion(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewVolumeResource()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	// newState returns a state of a volume with the given region.
+	newState := func(t *testing.T, region *string) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+
+		diags := state.SetAttribute(ctx, path.Root("name"), "data")
+		diags.Append(state.SetAttribute(ctx, path.Root("region"), types.StringPointerValue(region))...)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		return state
+	}
+
+	testCases := map[string]struct {
+		defaultRegion  string
+		configRegion   *string
+		stateRegion    *string
+		expectedRegion string
+		expectReplace  bool
+		expectError    bool
+	}{
+		"configured region": {
+			defaultRegion:  "NORD-NO-KRS-1",
+			configRegion:   pointer("EUW-NL-AMS-1"),
+			expectedRegion: "EUW-NL-AMS-1",
+		},
+		"default region on create": {
+			defaultRegion:  "NORD-NO-KRS-1",
+			expectedRegion: "NORD-NO-KRS-1",
+		},
+		"unchanged default region": {
+			defaultRegion:  "NORD-NO-KRS-1",
+			stateRegion:    pointer("NORD-NO-KRS-1"),
+			expectedRegion: "NORD-NO-KRS-1",
+		},
+		"changed default region": {
+			defaultRegion:  "EUW-NL-AMS-1",
+			stateRegion:    pointer("NORD-NO-KRS-1"),
+			expectedRegion: "EUW-NL-AMS-1",
+			expectReplace:  true,
+		},
+		"missing region": {
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := newState(t, testCase.configRegion)
+
+			// The region of the plan is taken from the state when it is not configured
+			plan := config
+			if testCase.configRegion == nil && testCase.stateRegion != nil {
+				plan = newState(t, testCase.stateRegion)
+			}
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if testCase.stateRegion != nil {
+				state = newState(t, testCase.stateRegion)
+			}
+
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				State:  state,
+			}
+			resp := &fwresource.ModifyPlanResponse{
+				Plan: req.Plan,
+			}
+
+			modifyPlanDefaultRegion(ctx, &Client{DefaultRegion: testCase.defaultRegion}, req, resp)
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var region types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("region"), &region)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if region.ValueString() != testCase.expectedRegion {
+				t.Errorf("expected region %q, got %q", testCase.expectedRegion, region.ValueString())
+			}
+
+			if replace := len(resp.RequiresReplace) > 0; replace != testCase.expectReplace {
+				t.Errorf("expected requires replace %t, got %t", testCase.expectReplace, replace)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,10 +24,21 @@ import (
 var (
 	_ resource.Resource                     = &SecurityGroupResource{}
 	_ resource.ResourceWithConfigure        = &SecurityGroupResource{}
+	_ resource.ResourceWithModifyPlan       = &SecurityGroupResource{}
 	_ resource.ResourceWithImportState      = &SecurityGroupResource{}
 	_ resource.ResourceWithConfigValidators = &SecurityGroupResource{}
+	_ resource.ResourceWithMoveState        = &SecurityGroupResource{}
+	_ resource.ResourceWithIdentity         = &SecurityGroupResource{}
 )
 
+// securityGroupErrorFieldAttributes maps the fields of the security group requests to their attributes.
+var securityGroupErrorFieldAttributes = map[string]string{
+	"description": "description",
+	"name":        "name",
+	"region":      "region",
+	"rules":       "rules",
+}
+
 func NewSecurityGroupResource() resource.Resource {
 	return &SecurityGroupResource{}
 }
@@ -35,6 +47,7 @@ func NewSecurityGroupResource() resource.Resource {
 type SecurityGroupResource struct {
 	ResourceWithClient
 	ResourceWithTimeout
+	ResourceWithIdIdentity
 }
 
 func (r *SecurityGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -74,9 +87,11 @@ func (r *SecurityGroupResource) Schema(ctx context.Context, req resource.SchemaR
 				Required:            true,
 			}),
 			"region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "The region identifier.",
-				Required:            true,
+				MarkdownDescription: "The region identifier. Defaults to the `default_region` of the provider.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -141,6 +156,10 @@ func (r *SecurityGroupResource) ConfigValidators(ctx context.Context) []resource
 	}
 }
 
+func (r *SecurityGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanDefaultRegion(ctx, r.client, req, resp)
+}
+
 func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data SecurityGroupResourceModel
 
@@ -150,7 +169,7 @@ func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_security_group").Create)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -190,7 +209,7 @@ func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateR
 
 	securityGroupResponse := response.JSON201
 	if securityGroupResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("create security_group", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("create security_group", securityGroupErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -205,6 +224,8 @@ func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateR
 
 	tflog.Trace(ctx, "created a security group resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -213,48 +234,69 @@ func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateR
 
 	securityGroupId := securityGroupResponse.SecurityGroup.Id
 
+	securityGroup, diags := r.waitForCreated(ctx, securityGroupId)
+	if diags.HasError() && isWaitTimeout(ctx) {
+		resp.Diagnostics.Append(markProvisioning(ctx, resp.Private, "security group")...)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, securityGroup)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if securityGroup.Status == sagadata.SecurityGroupStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling security_group", ErrResourceInErrorState))
+	}
+}
+
+// securityGroupProvisioned returns whether the security group finished provisioning.
+func securityGroupProvisioned(securityGroup *sagadata.SecurityGroup) bool {
+	return securityGroup.Status == sagadata.SecurityGroupStatusCreated || securityGroup.Status == sagadata.SecurityGroupStatusError
+}
+
+// waitForCreated polls the security group until it finished provisioning.
+func (r *SecurityGroupResource) waitForCreated(ctx context.Context, securityGroupId string) (*sagadata.SecurityGroup, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	for {
 		err := r.client.PollingWait(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling security_group", err))
-			return
+			diags.AddError("Polling Error", generateErrorMessage("polling security_group", err))
+			return nil, diags
 		}
 
 		tflog.Trace(ctx, "polling a security group resource")
 
 		response, err := r.client.GetSecurityGroupWithResponse(ctx, securityGroupId)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling security_group", err))
-			return
+			diags.AddError("Client Error", generateErrorMessage("polling security_group", err))
+			return nil, diags
 		}
 
 		securityGroupResponse := response.JSON200
 		if securityGroupResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling security_group", ErrorResponse{
+			diags.Append(newClientErrorDiagnostic("polling security_group", nil, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
 			}))
-			return
+			return nil, diags
 		}
 
-		status := securityGroupResponse.SecurityGroup.Status
-		if status == sagadata.SecurityGroupStatusCreated || status == sagadata.SecurityGroupStatusError {
-			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &securityGroupResponse.SecurityGroup)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			if status == sagadata.SecurityGroupStatusError {
-				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling security_group", ErrResourceInErrorState))
-			}
-			return
+		if securityGroupProvisioned(&securityGroupResponse.SecurityGroup) {
+			return &securityGroupResponse.SecurityGroup, diags
 		}
 	}
 }
@@ -268,7 +310,7 @@ func (r *SecurityGroupResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_security_group").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -277,7 +319,7 @@ func (r *SecurityGroupResource) Read(ctx context.Context, req resource.ReadReque
 
 	securityGroupId := data.Id.ValueString()
 
-	response, err := r.client.GetSecurityGroupWithResponse(ctx, securityGroupId)
+	response, err := r.client.GetSecurityGroupCachedWithResponse(ctx, securityGroupId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read security_group", err))
 		return
@@ -285,7 +327,7 @@ func (r *SecurityGroupResource) Read(ctx context.Context, req resource.ReadReque
 
 	securityGroupResponse := response.JSON200
 	if securityGroupResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read security_group", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read security_group", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -293,13 +335,21 @@ func (r *SecurityGroupResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &securityGroupResponse.SecurityGroup)...)
+	securityGroup, diags := resumeProvisioning(ctx, resp.Private, "security group", securityGroupId, &securityGroupResponse.SecurityGroup, securityGroupProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, securityGroup)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Trace(ctx, "read a security group resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -313,13 +363,19 @@ func (r *SecurityGroupResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update, r.client.DefaultTimeouts("sagadata_security_group").Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 	defer cancel()
 
+	_, diags := resumeProvisioning(ctx, resp.Private, "security group", data.Id.ValueString(), nil, securityGroupProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	body := sagadata.UpdateSecurityGroupJSONRequestBody{}
 
 	body.Description = pointer(data.Description.ValueString())
@@ -357,7 +413,7 @@ func (r *SecurityGroupResource) Update(ctx context.Context, req resource.UpdateR
 
 	securityGroupResponse := response.JSON200
 	if securityGroupResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("update security_group", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("update security_group", securityGroupErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -395,7 +451,7 @@ func (r *SecurityGroupResource) Update(ctx context.Context, req resource.UpdateR
 
 		securityGroupResponse := response.JSON200
 		if securityGroupResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling security_group", ErrorResponse{
+			resp.Diagnostics.Append(newClientErrorDiagnostic("polling security_group", nil, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
@@ -433,7 +489,7 @@ func (r *SecurityGroupResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete, r.client.DefaultTimeouts("sagadata_security_group").Delete)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -449,7 +505,7 @@ func (r *SecurityGroupResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 
 	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("delete security_group", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("delete security_group", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -479,5 +535,11 @@ func (r *SecurityGroupResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *SecurityGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp, path.Root("id"), "security group", r.client, listSecurityGroupImportCandidates)
+}
+
+func (r *SecurityGroupResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		genesisCloudStateMover("_security_group", nil),
+	}
 }
@@ -3,27 +3,53 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/sagadata-public/sagadata-go"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/defaultplanmodifier"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var (
-	_ resource.Resource                = &SnapshotResource{}
-	_ resource.ResourceWithConfigure   = &SnapshotResource{}
-	_ resource.ResourceWithImportState = &SnapshotResource{}
+	_ resource.Resource                     = &SnapshotResource{}
+	_ resource.ResourceWithConfigure        = &SnapshotResource{}
+	_ resource.ResourceWithImportState      = &SnapshotResource{}
+	_ resource.ResourceWithConfigValidators = &SnapshotResource{}
+	_ resource.ResourceWithModifyPlan       = &SnapshotResource{}
+	_ resource.ResourceWithMoveState        = &SnapshotResource{}
+	_ resource.ResourceWithIdentity         = &SnapshotResource{}
 )
 
+// snapshotErrorFieldAttributes maps the fields of the snapshot requests to their attributes.
+var snapshotErrorFieldAttributes = map[string]string{
+	"name":              "name",
+	"region":            "region",
+	"replicated_region": "replicated_region",
+}
+
+// snapshotCopyErrorFieldAttributes maps the fields of the snapshot copy
+// requests to their attributes.
+var snapshotCopyErrorFieldAttributes = map[string]string{
+	"name":   "name",
+	"region": "replica_regions",
+}
+
 func NewSnapshotResource() resource.Resource {
 	return &SnapshotResource{}
 }
@@ -32,6 +58,7 @@ func NewSnapshotResource() resource.Resource {
 type SnapshotResource struct {
 	ResourceWithClient
 	ResourceWithTimeout
+	ResourceWithIdIdentity
 }
 
 func (r *SnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,6 +89,33 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "The human-readable name for the snapshot.",
 				Required:            true,
 			}),
+			"replica_regions": resourceenhancer.Attribute(ctx, schema.SetAttribute{
+				MarkdownDescription: "The regions to which the snapshot is replicated as managed copies. Copies are created and deleted in place when regions are added or removed, and missing copies are recreated. It cannot contain the region of the snapshot itself.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(sliceStringify(sagadata.AllRegions)...)),
+				},
+			}),
+			"replicas": schema.MapNestedAttribute{
+				MarkdownDescription: "The managed copies of the snapshot keyed by region.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The unique ID of the snapshot copy.",
+							Computed:            true,
+						}),
+						"status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The status of the snapshot copy.",
+							Computed:            true,
+						}),
+					},
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"replicated_region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "Target region for snapshot replication. When specified, also creates a copy of the snapshot in the given region. If omitted, the snapshot exists only in the current region.",
 				Required:            false,
@@ -89,6 +143,13 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.RequiresReplace(),
 				},
 			}),
+			"source_volume_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The id of the source volume from which this snapshot was derived.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			}),
 			"size": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
 				MarkdownDescription: "The storage size of this snapshot given in GiB.",
 				Computed:            true,
@@ -106,7 +167,7 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 
 			// Internal
 			"retain_on_delete": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
-				MarkdownDescription: "Flag to retain the snapshot when the resource is deleted.",
+				MarkdownDescription: "Flag to retain the snapshot and its copies when the resource is deleted or a copy is removed from `replica_regions`.",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.Bool{
@@ -119,6 +180,92 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 	}
 }
 
+func (r *SnapshotResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("source_instance_id"),
+			path.MatchRoot("source_snapshot_id"),
+			path.MatchRoot("source_volume_id"),
+		),
+		// The region is derived from the source instance or volume
+		resourcevalidator.Conflicting(
+			path.MatchRoot("source_instance_id"),
+			path.MatchRoot("region"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("source_volume_id"),
+			path.MatchRoot("region"),
+		),
+		// Cloning a snapshot requires the target region and cannot be replicated
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("source_snapshot_id"),
+			path.MatchRoot("region"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("source_snapshot_id"),
+			path.MatchRoot("replicated_region"),
+		),
+		// Unmanaged and managed replication are mutually exclusive
+		resourcevalidator.Conflicting(
+			path.MatchRoot("replicated_region"),
+			path.MatchRoot("replica_regions"),
+		),
+	}
+}
+
+func (r *SnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan SnapshotResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	replicaRegions, diag := plan.GetReplicaRegions(ctx)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The region is only known here when it is configured or in the state
+	if !plan.Region.IsUnknown() {
+		resp.Diagnostics.Append(checkSnapshotReplicaRegions(plan.Region.ValueString(), replicaRegions)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state SnapshotResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	replicas, diag := state.GetReplicas(ctx)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The copies are only known after they have been added or removed. This
+	// includes copies which are missing from the state, e.g. after they were
+	// deleted outside of Terraform or could not be created, which are created
+	// by the update.
+	missing, removed := snapshotReplicaChanges(replicaRegions, replicas)
+	if plan.ReplicaRegions.IsUnknown() || len(missing) > 0 || len(removed) > 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("replicas"), types.MapUnknown(types.ObjectType{AttrTypes: snapshotReplicaAttrTypes}))...)
+	}
+}
+
 func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data SnapshotResourceModel
 
@@ -128,40 +275,16 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_snapshot").Create)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 	defer cancel()
 
-	if data.SourceInstanceId.IsNull() && data.SourceSnapshotId.IsNull() {
-		resp.Diagnostics.AddError(
-			"Invalid Configuration",
-			"Either 'source_instance_id' or 'source_snapshot_id' must be specified.",
-		)
-		return
-	}
-
-	if !data.SourceInstanceId.IsNull() && !data.SourceSnapshotId.IsNull() {
-		resp.Diagnostics.AddError(
-			"Invalid Configuration",
-			"Can only specify either 'source_instance_id' or 'source_snapshot_id', not both at the same time.",
-		)
-		return
-	}
-
 	var snapshotResponse *sagadata.SingleSnapshotResponse
 
 	if !data.SourceInstanceId.IsNull() {
-		if data.Region.ValueString() != "" {
-			resp.Diagnostics.AddError(
-				"Invalid Configuration",
-				"When specifying a source instance, the 'region' specification won't take effect",
-			)
-			return
-
-		}
 		body := sagadata.CreateInstanceSnapshotJSONRequestBody{}
 
 		body.Name = data.Name.ValueString()
@@ -180,7 +303,7 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 
 		snapshotResponse = response.JSON201
 		if snapshotResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("create snapshot", ErrorResponse{
+			resp.Diagnostics.Append(newClientErrorDiagnostic("create snapshot", snapshotErrorFieldAttributes, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
@@ -188,22 +311,6 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 			return
 		}
 	} else if !data.SourceSnapshotId.IsNull() {
-		if data.Region.IsNull() {
-			resp.Diagnostics.AddError(
-				"Invalid Configuration",
-				"When specifying `source_snapshot_id` as the snapshot source, you must be specified the `region`.",
-			)
-			return
-		}
-
-		if !data.ReplicatedRegion.IsNull() {
-			resp.Diagnostics.AddError(
-				"Invalid Configuration",
-				"When specifying `source_snapshot_id` as the snapshot source, you cannot specify the `replicated_region`.",
-			)
-			return
-		}
-
 		body := sagadata.CloneSnapshotJSONRequestBody{}
 
 		body.Name = data.Name.ValueString()
@@ -220,7 +327,33 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 
 		snapshotResponse = response.JSON201
 		if snapshotResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("clone snapshot", ErrorResponse{
+			resp.Diagnostics.Append(newClientErrorDiagnostic("clone snapshot", snapshotErrorFieldAttributes, ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return
+		}
+	} else if !data.SourceVolumeId.IsNull() {
+		body := sagadata.CreateVolumeSnapshotJSONRequestBody{}
+
+		body.Name = data.Name.ValueString()
+
+		volumeId := data.SourceVolumeId.ValueString()
+
+		if !data.ReplicatedRegion.IsNull() {
+			body.ReplicatedRegion = pointer(sagadata.Region(data.ReplicatedRegion.ValueString()))
+		}
+
+		response, err := r.client.CreateVolumeSnapshotWithResponse(ctx, volumeId, body)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", generateErrorMessage("create volume snapshot", err))
+			return
+		}
+
+		snapshotResponse = response.JSON201
+		if snapshotResponse == nil {
+			resp.Diagnostics.Append(newClientErrorDiagnostic("create volume snapshot", snapshotErrorFieldAttributes, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
@@ -234,8 +367,17 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	replicas := map[string]SnapshotReplicaModel{}
+
+	resp.Diagnostics.Append(data.SetReplicas(ctx, replicas)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Trace(ctx, "created a snapshot resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -244,48 +386,109 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 
 	snapshotId := snapshotResponse.Snapshot.Id
 
+	snapshot, diags := r.waitForCreated(ctx, snapshotId)
+	if diags.HasError() && isWaitTimeout(ctx) {
+		resp.Diagnostics.Append(markProvisioning(ctx, resp.Private, "snapshot")...)
+
+		// The copies are missing from the replicas, which plans an update creating them
+		if !data.ReplicaRegions.IsNull() && len(data.ReplicaRegions.Elements()) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Snapshot Copies Pending",
+				"The copies of the snapshot in replica_regions were not created yet. The next apply creates them once the snapshot finished provisioning.",
+			)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, snapshot)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if snapshot.Status == sagadata.SnapshotStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling snapshot", ErrResourceInErrorState))
+		return
+	}
+
+	replicaRegions, diag := data.GetReplicaRegions(ctx)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkSnapshotReplicaRegions(data.Region.ValueString(), replicaRegions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, region := range replicaRegions {
+		replica, diag := r.createReplica(ctx, snapshotId, data.Name.ValueString(), region)
+		if replica != nil {
+			replicas[region] = *replica
+
+			resp.Diagnostics.Append(data.SetReplicas(ctx, replicas)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Save data into Terraform state, also a copy in the error status so that it is cleaned up with the resource
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
+
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+}
+
+// snapshotProvisioned returns whether the snapshot finished provisioning.
+func snapshotProvisioned(snapshot *sagadata.Snapshot) bool {
+	return snapshot.Status == sagadata.SnapshotStatusCreated || snapshot.Status == sagadata.SnapshotStatusError
+}
+
+// waitForCreated polls the snapshot until it finished provisioning.
+func (r *SnapshotResource) waitForCreated(ctx context.Context, snapshotId string) (*sagadata.Snapshot, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	for {
 		err := r.client.PollingWait(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling snapshot", err))
-			return
+			diags.AddError("Polling Error", generateErrorMessage("polling snapshot", err))
+			return nil, diags
 		}
 
 		tflog.Trace(ctx, "polling a snapshot resource")
 
 		response, err := r.client.GetSnapshotWithResponse(ctx, snapshotId)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling snapshot", err))
-			return
+			diags.AddError("Client Error", generateErrorMessage("polling snapshot", err))
+			return nil, diags
 		}
 
 		snapshotResponse := response.JSON200
 		if snapshotResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling snapshot", ErrorResponse{
+			diags.Append(newClientErrorDiagnostic("polling snapshot", nil, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
 			}))
-			return
+			return nil, diags
 		}
 
-		status := snapshotResponse.Snapshot.Status
-		if status == sagadata.SnapshotStatusCreated || status == sagadata.SnapshotStatusError {
-			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &snapshotResponse.Snapshot)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			if status == sagadata.SnapshotStatusError {
-				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling snapshot", ErrResourceInErrorState))
-			}
-			return
+		if snapshotProvisioned(&snapshotResponse.Snapshot) {
+			return &snapshotResponse.Snapshot, diags
 		}
 	}
 }
@@ -299,7 +502,7 @@ func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_snapshot").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -308,7 +511,7 @@ func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	snapshotId := data.Id.ValueString()
 
-	response, err := r.client.GetSnapshotWithResponse(ctx, snapshotId)
+	response, err := r.client.GetSnapshotCachedWithResponse(ctx, snapshotId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read snapshot", err))
 		return
@@ -316,7 +519,7 @@ func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	snapshotResponse := response.JSON200
 	if snapshotResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read snapshot", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read snapshot", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -324,19 +527,65 @@ func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &snapshotResponse.Snapshot)...)
+	snapshot, diags := resumeProvisioning(ctx, resp.Private, "snapshot", snapshotId, &snapshotResponse.Snapshot, snapshotProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, snapshot)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	replicas, diag := data.GetReplicas(ctx)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for region, replica := range replicas {
+		response, err := r.client.GetSnapshotCachedWithResponse(ctx, replica.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", generateErrorMessage("read snapshot copy", err))
+			return
+		}
+
+		if response.StatusCode() == 404 {
+			tflog.Info(ctx, "snapshot copy no longer exists", map[string]interface{}{"region": region})
+			delete(replicas, region)
+			continue
+		}
+
+		snapshotResponse := response.JSON200
+		if snapshotResponse == nil {
+			resp.Diagnostics.Append(newClientErrorDiagnostic("read snapshot copy", nil, ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return
+		}
+
+		resp.Diagnostics.Append(replica.PopulateFromClientResponse(ctx, &snapshotResponse.Snapshot)...)
+		replicas[region] = replica
+	}
+
+	resp.Diagnostics.Append(data.SetReplicas(ctx, replicas)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Trace(ctx, "read a snapshot resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *SnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data SnapshotResourceModel
+	var data, state SnapshotResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -344,13 +593,25 @@ func (r *SnapshotResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update, r.client.DefaultTimeouts("sagadata_snapshot").Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 	defer cancel()
 
+	_, diags := resumeProvisioning(ctx, resp.Private, "snapshot", data.Id.ValueString(), nil, snapshotProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	body := sagadata.UpdateSnapshotJSONRequestBody{}
 
 	body.Name = pointer(data.Name.ValueString())
@@ -365,7 +626,7 @@ func (r *SnapshotResource) Update(ctx context.Context, req resource.UpdateReques
 
 	snapshotResponse := response.JSON200
 	if snapshotResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("update snapshot", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("update snapshot", snapshotErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -378,10 +639,103 @@ func (r *SnapshotResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	replicas, diag := state.GetReplicas(ctx)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	replicaRegions, diag := data.GetReplicaRegions(ctx)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.SetReplicas(ctx, replicas)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Trace(ctx, "updated a snapshot resource")
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, removed := snapshotReplicaChanges(replicaRegions, replicas)
+
+	for _, region := range removed {
+		replica := replicas[region]
+
+		if data.RetainOnDelete.ValueBool() {
+			resp.Diagnostics.AddWarning(
+				"Snapshot copy is retained",
+				fmt.Sprintf("The snapshot copy with id %q in region %q was removed from the state but the snapshot is retained.", replica.Id.ValueString(), region),
+			)
+		} else {
+			resp.Diagnostics.Append(r.deleteReplica(ctx, replica.Id.ValueString())...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		delete(replicas, region)
+
+		resp.Diagnostics.Append(data.SetReplicas(ctx, replicas)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Save updated data into Terraform state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	for _, region := range replicaRegions {
+		if replica, ok := replicas[region]; ok {
+			if data.Name.Equal(state.Name) {
+				continue
+			}
+
+			response, err := r.client.UpdateSnapshotWithResponse(ctx, replica.Id.ValueString(), body)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", generateErrorMessage("update snapshot copy", err))
+				return
+			}
+
+			if response.JSON200 == nil {
+				resp.Diagnostics.Append(newClientErrorDiagnostic("update snapshot copy", snapshotErrorFieldAttributes, ErrorResponse{
+					Body:         response.Body,
+					HTTPResponse: response.HTTPResponse,
+					Error:        response.JSONDefault,
+				}))
+				return
+			}
+			continue
+		}
+
+		replica, diag := r.createReplica(ctx, snapshotId, data.Name.ValueString(), region)
+		if replica != nil {
+			replicas[region] = *replica
+
+			resp.Diagnostics.Append(data.SetReplicas(ctx, replicas)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Save updated data into Terraform state, also a copy in the error status so that it is cleaned up with the resource
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
+
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 }
 
 func (r *SnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -393,7 +747,7 @@ func (r *SnapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete, r.client.DefaultTimeouts("sagadata_snapshot").Delete)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -405,47 +759,188 @@ func (r *SnapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 	if data.RetainOnDelete.ValueBool() {
 		resp.Diagnostics.AddWarning(
 			"Snapshot is retained",
-			fmt.Sprintf("The snapshot resource with id %q was deleted from the state but the snapshot is retained.", snapshotId),
+			fmt.Sprintf("The snapshot resource with id %q was deleted from the state but the snapshot and its copies are retained.", snapshotId),
 		)
 		return
 	}
 
+	replicas, diag := data.GetReplicas(ctx)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, replica := range replicas {
+		resp.Diagnostics.Append(r.deleteReplica(ctx, replica.Id.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.deleteReplica(ctx, snapshotId)...)
+}
+
+// snapshotReplicaChanges returns the regions of the copies which are missing
+// and the regions of the copies which are no longer wanted, both sorted.
+func snapshotReplicaChanges(replicaRegions []string, replicas map[string]SnapshotReplicaModel) (missing []string, removed []string) {
+	for _, region := range replicaRegions {
+		if _, ok := replicas[region]; !ok {
+			missing = append(missing, region)
+		}
+	}
+
+	for region := range replicas {
+		if !slices.Contains(replicaRegions, region) {
+			removed = append(removed, region)
+		}
+	}
+
+	slices.Sort(missing)
+	slices.Sort(removed)
+
+	return missing, removed
+}
+
+// checkSnapshotReplicaRegions returns an error when a copy is requested in the
+// region of the snapshot itself.
+func checkSnapshotReplicaRegions(region string, replicaRegions []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if region != "" && slices.Contains(replicaRegions, region) {
+		diags.AddAttributeError(
+			path.Root("replica_regions"),
+			"Invalid Replica Region",
+			fmt.Sprintf("The snapshot cannot be replicated to its own region %q. Remove it from replica_regions.", region),
+		)
+	}
+
+	return diags
+}
+
+// createReplica clones the snapshot into the given region and waits until the copy is created.
+func (r *SnapshotResource) createReplica(ctx context.Context, snapshotId string, name string, region string) (*SnapshotReplicaModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	body := sagadata.CloneSnapshotJSONRequestBody{}
+
+	body.Name = name
+
+	body.Region = sagadata.Region(region)
+
+	response, err := r.client.CloneSnapshotWithResponse(ctx, snapshotId, body)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage("create snapshot copy", err))
+		return nil, diags
+	}
+
+	snapshotResponse := response.JSON201
+	if snapshotResponse == nil {
+		diags.Append(newClientErrorDiagnostic("create snapshot copy", snapshotCopyErrorFieldAttributes, ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return nil, diags
+	}
+
+	tflog.Info(ctx, "creating snapshot copy", map[string]interface{}{"region": region})
+
+	replicaId := snapshotResponse.Snapshot.Id
+
+	for {
+		err := r.client.PollingWait(ctx)
+		if err != nil {
+			diags.AddError("Polling Error", generateErrorMessage("polling snapshot copy", err))
+			return nil, diags
+		}
+
+		tflog.Trace(ctx, "polling a snapshot copy")
+
+		response, err := r.client.GetSnapshotWithResponse(ctx, replicaId)
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage("polling snapshot copy", err))
+			return nil, diags
+		}
+
+		snapshotResponse := response.JSON200
+		if snapshotResponse == nil {
+			diags.Append(newClientErrorDiagnostic("polling snapshot copy", nil, ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return nil, diags
+		}
+
+		status := snapshotResponse.Snapshot.Status
+		if status == sagadata.SnapshotStatusCreated || status == sagadata.SnapshotStatusError {
+			replica := &SnapshotReplicaModel{}
+
+			diags.Append(replica.PopulateFromClientResponse(ctx, &snapshotResponse.Snapshot)...)
+
+			// The copy is recorded so that it is cleaned up with the resource
+			if status == sagadata.SnapshotStatusError {
+				diags.AddError("Provisioning Error", generateErrorMessage(fmt.Sprintf("polling snapshot copy in region %s", region), ErrResourceInErrorState))
+			}
+			return replica, diags
+		}
+	}
+}
+
+// deleteReplica deletes the snapshot with the given id and waits until it is
+// gone. A snapshot which was already deleted outside of Terraform is skipped.
+func (r *SnapshotResource) deleteReplica(ctx context.Context, snapshotId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
 	response, err := r.client.DeleteSnapshotWithResponse(ctx, snapshotId)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", generateErrorMessage("delete snapshot", err))
-		return
+		diags.AddError("Client Error", generateErrorMessage("delete snapshot", err))
+		return diags
+	}
+
+	if response.StatusCode() == 404 {
+		tflog.Info(ctx, "snapshot already deleted", map[string]interface{}{"id": snapshotId})
+		return diags
 	}
 
 	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("delete snapshot", ErrorResponse{
+		diags.Append(newClientErrorDiagnostic("delete snapshot", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
 		}))
-		return
+		return diags
 	}
 
 	for {
 		err := r.client.PollingWait(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling snapshot", err))
-			return
+			diags.AddError("Polling Error", generateErrorMessage("polling snapshot", err))
+			return diags
 		}
 
 		tflog.Trace(ctx, "polling a snapshot resource")
 
 		response, err := r.client.GetSnapshotWithResponse(ctx, snapshotId)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling snapshot", err))
-			return
+			diags.AddError("Client Error", generateErrorMessage("polling snapshot", err))
+			return diags
 		}
 
 		if response.StatusCode() == 404 {
-			return
+			return diags
 		}
 	}
 }
 
 func (r *SnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp, path.Root("id"), "snapshot", r.client, listSnapshotImportCandidates)
+}
+
+func (r *SnapshotResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		genesisCloudStateMover("_snapshot", map[string]string{
+			"instance_id": "source_instance_id",
+		}),
+	}
 }
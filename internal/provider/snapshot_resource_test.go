@@ -1,9 +1,20 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
 
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -47,3 +58,402 @@ func TestAccSnapshotResource(t *testing.T) {
 		},
 	})
 }
+
+func TestSnapshotResourceConfigValidators(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewSnapshotResource().(*SnapshotResource)
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	optional := []string{"source_instance_id", "source_snapshot_id", "source_volume_id", "region", "replicated_region"}
+
+	// Every combination of the optional attributes being configured
+	for mask := 0; mask < 1<<len(optional); mask++ {
+		configured := map[string]bool{}
+		var names []string
+		for i, name := range optional {
+			if mask&(1<<i) != 0 {
+				configured[name] = true
+				names = append(names, name)
+			}
+		}
+
+		sources := 0
+		for _, name := range optional[:3] {
+			if configured[name] {
+				sources++
+			}
+		}
+
+		expectValid := sources == 1 &&
+			!(configured["source_instance_id"] && configured["region"]) &&
+			!(configured["source_volume_id"] && configured["region"]) &&
+			configured["source_snapshot_id"] == configured["region"] &&
+			!(configured["source_snapshot_id"] && configured["replicated_region"])
+
+		t.Run(fmt.Sprintf("%v", names), func(t *testing.T) {
+			values := map[string]tftypes.Value{}
+			for name, attrType := range objectType.AttributeTypes {
+				switch {
+				case name == "name":
+					values[name] = tftypes.NewValue(attrType, "example")
+				case configured[name] && name == "region", configured[name] && name == "replicated_region":
+					values[name] = tftypes.NewValue(attrType, "NORD-NO-KRS-1")
+				case configured[name]:
+					values[name] = tftypes.NewValue(attrType, "6b4b6d6e-3f0c-4d2b-9d0c-7b1f2e1b1e7a")
+				default:
+					values[name] = tftypes.NewValue(attrType, nil)
+				}
+			}
+
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(objectType, values),
+			}
+
+			var diags diag.Diagnostics
+			for _, validator := range r.ConfigValidators(ctx) {
+				validateResp := &fwresource.ValidateConfigResponse{}
+				validator.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, validateResp)
+				diags.Append(validateResp.Diagnostics...)
+			}
+
+			if expectValid && diags.HasError() {
+				t.Errorf("expected configuration to be valid, got: %v", diags)
+			}
+			if !expectValid && !diags.HasError() {
+				t.Errorf("expected configuration to be invalid")
+			}
+		})
+	}
+}
+
+func TestSnapshotReplicaChanges(t *testing.T) {
+	replicas := map[string]SnapshotReplicaModel{
+		"EUW-NL-AMS-1": {Id: types.StringValue("replica-ams"), Status: types.StringValue("created")},
+		"NA-CA-FTS-1":  {Id: types.StringValue("replica-fts"), Status: types.StringValue("created")},
+	}
+
+	testCases := map[string]struct {
+		replicaRegions  []string
+		expectedMissing []string
+		expectedRemoved []string
+	}{
+		"unchanged": {
+			replicaRegions: []string{"NA-CA-FTS-1", "EUW-NL-AMS-1"},
+		},
+		"added": {
+			replicaRegions:  []string{"EUW-NL-AMS-1", "NA-CA-FTS-1", "NA-CA-MNZ-1"},
+			expectedMissing: []string{"NA-CA-MNZ-1"},
+		},
+		"removed": {
+			replicaRegions:  []string{"EUW-NL-AMS-1"},
+			expectedRemoved: []string{"NA-CA-FTS-1"},
+		},
+		"none": {
+			expectedRemoved: []string{"EUW-NL-AMS-1", "NA-CA-FTS-1"},
+		},
+		"added and removed": {
+			replicaRegions:  []string{"NA-CA-MNZ-1", "EUW-NL-AMS-1", "EUC-DE-MUC-1"},
+			expectedMissing: []string{"EUC-DE-MUC-1", "NA-CA-MNZ-1"},
+			expectedRemoved: []string{"NA-CA-FTS-1"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			missing, removed := snapshotReplicaChanges(testCase.replicaRegions, replicas)
+
+			if fmt.Sprint(missing) != fmt.Sprint(testCase.expectedMissing) {
+				t.Errorf("expected missing %v, got %v", testCase.expectedMissing, missing)
+			}
+			if fmt.Sprint(removed) != fmt.Sprint(testCase.expectedRemoved) {
+				t.Errorf("expected removed %v, got %v", testCase.expectedRemoved, removed)
+			}
+		})
+	}
+}
+
+func TestSnapshotResourceModifyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewSnapshotResource().(*SnapshotResource)
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	// newState returns a state of a snapshot with the given copies.
+	newState := func(t *testing.T, replicaRegions []string, replicas map[string]SnapshotReplicaModel) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+
+		var diags diag.Diagnostics
+		diags.Append(state.SetAttribute(ctx, path.Root("name"), "example")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("region"), "NORD-NO-KRS-1")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("replica_regions"), replicaRegions)...)
+		if replicas != nil {
+			diags.Append(state.SetAttribute(ctx, path.Root("replicas"), replicas)...)
+		}
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		return state
+	}
+
+	ams := map[string]SnapshotReplicaModel{
+		"EUW-NL-AMS-1": {Id: types.StringValue("replica-ams"), Status: types.StringValue("created")},
+	}
+
+	testCases := map[string]struct {
+		stateRegions  []string
+		stateReplicas map[string]SnapshotReplicaModel
+		planRegions   []string
+		create        bool
+		expectUnknown bool
+		expectError   bool
+	}{
+		"unchanged": {
+			stateRegions:  []string{"EUW-NL-AMS-1"},
+			stateReplicas: ams,
+			planRegions:   []string{"EUW-NL-AMS-1"},
+		},
+		"added region": {
+			stateRegions:  []string{"EUW-NL-AMS-1"},
+			stateReplicas: ams,
+			planRegions:   []string{"EUW-NL-AMS-1", "NA-CA-FTS-1"},
+			expectUnknown: true,
+		},
+		"removed region": {
+			stateRegions:  []string{"EUW-NL-AMS-1"},
+			stateReplicas: ams,
+			expectUnknown: true,
+		},
+		"copy deleted outside of terraform": {
+			stateRegions:  []string{"EUW-NL-AMS-1", "NA-CA-FTS-1"},
+			stateReplicas: ams,
+			planRegions:   []string{"EUW-NL-AMS-1", "NA-CA-FTS-1"},
+			expectUnknown: true,
+		},
+		"copies not created before the create timeout": {
+			stateRegions:  []string{"EUW-NL-AMS-1"},
+			stateReplicas: map[string]SnapshotReplicaModel{},
+			planRegions:   []string{"EUW-NL-AMS-1"},
+			expectUnknown: true,
+		},
+		"own region": {
+			stateRegions:  []string{"EUW-NL-AMS-1"},
+			stateReplicas: ams,
+			planRegions:   []string{"EUW-NL-AMS-1", "NORD-NO-KRS-1"},
+			expectError:   true,
+		},
+		"own region on create": {
+			planRegions: []string{"NORD-NO-KRS-1"},
+			create:      true,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if !testCase.create {
+				state = newState(t, testCase.stateRegions, testCase.stateReplicas)
+			}
+
+			// The copies are planned from the state
+			plan := newState(t, testCase.planRegions, testCase.stateReplicas)
+
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				State:  state,
+			}
+			resp := &fwresource.ModifyPlanResponse{
+				Plan: req.Plan,
+			}
+
+			r.ModifyPlan(ctx, req, resp)
+
+			if testCase.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var replicas types.Map
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("replicas"), &replicas)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if replicas.IsUnknown() != testCase.expectUnknown {
+				t.Errorf("expected unknown replicas %t, got %s", testCase.expectUnknown, replicas)
+			}
+		})
+	}
+}
+
+// newTestClient returns a client of the test server of the handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(context.Background(), ClientConfig{
+		ClientConfig: sagadata.ClientConfig{
+			Endpoint: server.URL,
+			Token:    "test-token",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestSnapshotResourceDeleteReplica(t *testing.T) {
+	testCases := map[string]struct {
+		deleteStatus int
+		expectGet    bool
+		expectError  bool
+	}{
+		"deleted": {
+			deleteStatus: http.StatusNoContent,
+			expectGet:    true,
+		},
+		"already deleted": {
+			deleteStatus: http.StatusNotFound,
+		},
+		"conflict": {
+			deleteStatus: http.StatusConflict,
+			expectError:  true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+
+			r := &SnapshotResource{}
+			r.client = newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+				requests = append(requests, req.Method)
+
+				switch req.Method {
+				case http.MethodDelete:
+					w.WriteHeader(testCase.deleteStatus)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			diags := r.deleteReplica(context.Background(), "replica-ams")
+
+			if diags.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got %v", testCase.expectError, diags)
+			}
+
+			if gets := slices.Contains(requests, http.MethodGet); gets != testCase.expectGet {
+				t.Errorf("expected polling %t, got requests %v", testCase.expectGet, requests)
+			}
+		})
+	}
+}
+
+func TestSnapshotResourceUpdateReplicaError(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewSnapshotResource().(*SnapshotResource)
+	r.client = newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case req.Method == http.MethodPatch && req.URL.Path == "/v1/snapshots/snapshot-id":
+			_, _ = w.Write([]byte(`{"snapshot": {"id": "snapshot-id", "name": "example", "region": "NORD-NO-KRS-1", "status": "created"}}`))
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/snapshots/snapshot-id/clone":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"snapshot": {"id": "replica-ams", "name": "example", "region": "EUW-NL-AMS-1", "status": "creating"}}`))
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/snapshots/replica-ams":
+			_, _ = w.Write([]byte(`{"snapshot": {"id": "replica-ams", "name": "example", "region": "EUW-NL-AMS-1", "status": "error"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	// newState returns a state of a snapshot with the given copies.
+	newState := func(t *testing.T, replicaRegions []string, replicas map[string]SnapshotReplicaModel) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+
+		var diags diag.Diagnostics
+		diags.Append(state.SetAttribute(ctx, path.Root("id"), "snapshot-id")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("name"), "example")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("region"), "NORD-NO-KRS-1")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("replica_regions"), replicaRegions)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("replicas"), replicas)...)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		return state
+	}
+
+	state := newState(t, nil, map[string]SnapshotReplicaModel{})
+	plan := newState(t, []string{"EUW-NL-AMS-1"}, map[string]SnapshotReplicaModel{})
+
+	req := fwresource.UpdateRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+		State:  state,
+	}
+	resp := &fwresource.UpdateResponse{
+		State: tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+	}
+
+	r.Update(ctx, req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected an error for the copy in the error status")
+	}
+
+	// The failed copy is saved so that it is deleted with the resource
+	var replicas map[string]SnapshotReplicaModel
+	diags := resp.State.GetAttribute(ctx, path.Root("replicas"), &replicas)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	replica, ok := replicas["EUW-NL-AMS-1"]
+	if !ok || replica.Id.ValueString() != "replica-ams" || replica.Status.ValueString() != "error" {
+		t.Errorf("expected the copy in the error status to be saved, got %v", replicas)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/sagadata-public/sagadata-go"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -28,9 +29,18 @@ type SnapshotResourceModel struct {
 	// SourceSnapshotId The id of the source snapshot from which this snapsot was derived.
 	SourceSnapshotId types.String `tfsdk:"source_snapshot_id"`
 
+	// SourceVolumeId The id of the source volume from which this snapshot was derived.
+	SourceVolumeId types.String `tfsdk:"source_volume_id"`
+
 	// ReplicatedRegion The region identifier when the snapshot should be replicated.
 	ReplicatedRegion types.String `tfsdk:"replicated_region"`
 
+	// ReplicaRegions The regions the snapshot is replicated to as managed copies.
+	ReplicaRegions types.Set `tfsdk:"replica_regions"`
+
+	// Replicas The managed copies of the snapshot keyed by region.
+	Replicas types.Map `tfsdk:"replicas"`
+
 	// Size The storage size of this snapshot given in GiB.
 	Size types.Int64 `tfsdk:"size"`
 
@@ -60,6 +70,54 @@ func (data *SnapshotResourceModel) PopulateFromClientResponse(ctx context.Contex
 	if snapshot.SourceSnapshotId != nil {
 		data.SourceSnapshotId = types.StringValue(*snapshot.SourceSnapshotId)
 	}
+	if snapshot.SourceVolumeId != nil {
+		data.SourceVolumeId = types.StringValue(*snapshot.SourceVolumeId)
+	}
+
+	return
+}
+
+type SnapshotReplicaModel struct {
+	// Id The unique ID of the snapshot copy.
+	Id types.String `tfsdk:"id"`
+
+	// Status The status of the snapshot copy.
+	Status types.String `tfsdk:"status"`
+}
+
+var snapshotReplicaAttrTypes = map[string]attr.Type{
+	"id":     types.StringType,
+	"status": types.StringType,
+}
+
+func (data *SnapshotReplicaModel) PopulateFromClientResponse(ctx context.Context, snapshot *sagadata.Snapshot) (diag diag.Diagnostics) {
+	data.Id = types.StringValue(snapshot.Id)
+	data.Status = types.StringValue(string(snapshot.Status))
+
+	return
+}
+
+func (data *SnapshotResourceModel) GetReplicas(ctx context.Context) (replicas map[string]SnapshotReplicaModel, diag diag.Diagnostics) {
+	replicas = map[string]SnapshotReplicaModel{}
+
+	if data.Replicas.IsNull() || data.Replicas.IsUnknown() {
+		return
+	}
+
+	diag = data.Replicas.ElementsAs(ctx, &replicas, false)
+	return
+}
+
+func (data *SnapshotResourceModel) SetReplicas(ctx context.Context, replicas map[string]SnapshotReplicaModel) (diag diag.Diagnostics) {
+	data.Replicas, diag = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: snapshotReplicaAttrTypes}, replicas)
+	return
+}
+
+func (data *SnapshotResourceModel) GetReplicaRegions(ctx context.Context) (regions []string, diag diag.Diagnostics) {
+	if data.ReplicaRegions.IsNull() || data.ReplicaRegions.IsUnknown() {
+		return
+	}
 
+	diag = data.ReplicaRegions.ElementsAs(ctx, &regions, false)
 	return
 }
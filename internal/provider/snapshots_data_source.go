@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/sagadata-public/terraform-provider-sagadata/internal/datasourceenhancer"
+	"github.com/sagadata-public/terraform-provider-sagadata/internal/timedurationvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ datasource.DataSource              = &SnapshotsDataSource{}
+	_ datasource.DataSourceWithConfigure = &SnapshotsDataSource{}
+)
+
+func NewSnapshotsDataSource() datasource.DataSource {
+	return &SnapshotsDataSource{}
+}
+
+// SnapshotsDataSource defines the data source implementation.
+type SnapshotsDataSource struct {
+	DataSourceWithClient
+	DataSourceWithTimeout
+}
+
+func (d *SnapshotsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshots"
+}
+
+func (d *SnapshotsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Snapshots data source. The snapshots are ordered from newest to oldest, which allows to compute the snapshots to prune with a retention count or age.",
+
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"name_prefix": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: "Filter by the beginning of the snapshot name.",
+						Optional:            true,
+					}),
+					"older_than": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: "Filter by snapshots created longer ago than the given duration, for example \"168h\".",
+						Optional:            true,
+						Validators: []validator.String{
+							timedurationvalidator.Positive(),
+						},
+					}),
+					"region": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: "Filter by the region identifier.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(sliceStringify(sagadata.AllRegions)...),
+						},
+					}),
+					"source_instance_id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: "Filter by the id of the source instance.",
+						Optional:            true,
+					}),
+					"source_volume_id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: "Filter by the id of the source volume.",
+						Optional:            true,
+					}),
+				},
+			},
+			"snapshots": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"created_at": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The timestamp when this snapshot was created in RFC 3339.",
+							Computed:            true,
+						}),
+						"id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The unique ID of the snapshot.",
+							Computed:            true,
+						}),
+						"name": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The human-readable name for the snapshot.",
+							Computed:            true,
+						}),
+						"region": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The region identifier.",
+							Computed:            true,
+						}),
+						"size": datasourceenhancer.Attribute(ctx, schema.Int64Attribute{
+							MarkdownDescription: "The storage size of this snapshot given in GiB.",
+							Computed:            true,
+						}),
+						"source_instance_id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The id of the source instance from which this snapshot was derived.",
+							Computed:            true,
+						}),
+						"source_snapshot_id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The id of the source snapshot from which this snapshot was derived.",
+							Computed:            true,
+						}),
+						"source_volume_id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The id of the source volume from which this snapshot was derived.",
+							Computed:            true,
+						}),
+						"status": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The snapshot status.",
+							Computed:            true,
+						}),
+					},
+				},
+			},
+			"id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source itself.",
+				Computed:            true,
+			}),
+
+			// Internal
+			"timeouts": timeouts.Attributes(ctx),
+		},
+	}
+}
+
+func (d *SnapshotsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SnapshotsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := d.ContextWithTimeout(ctx, data.Timeouts.Read, d.client.DefaultTimeouts("sagadata_snapshots").Read)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	filter := data.Filter
+	if filter == nil {
+		filter = &SnapshotsFilterDataSourceModel{}
+	}
+
+	allSnapshots, diags := listSnapshots(ctx, d.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshots, err := filterSnapshots(allSnapshots, *filter, time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("Configuration Error", generateErrorMessage("parse older_than", err))
+		return
+	}
+
+	data.Snapshots = []SnapshotModel{}
+	for _, snapshot := range snapshots {
+		model := SnapshotModel{}
+		model.PopulateFromClientResponse(ctx, &snapshot)
+
+		data.Snapshots = append(data.Snapshots, model)
+	}
+
+	data.Id = types.StringValue("none")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterSnapshots returns the snapshots which match the filter at the time,
+// newest first.
+func filterSnapshots(allSnapshots []sagadata.Snapshot, filter SnapshotsFilterDataSourceModel, now time.Time) ([]sagadata.Snapshot, error) {
+	var createdBefore *time.Time
+
+	if !filter.OlderThan.IsNull() && !filter.OlderThan.IsUnknown() {
+		olderThan, err := time.ParseDuration(filter.OlderThan.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		createdBefore = pointer(now.Add(-olderThan))
+	}
+
+	var snapshots []sagadata.Snapshot
+
+	for _, snapshot := range allSnapshots {
+		if !filter.NamePrefix.IsNull() && !strings.HasPrefix(snapshot.Name, filter.NamePrefix.ValueString()) {
+			continue
+		}
+
+		if !filter.Region.IsNull() && string(snapshot.Region) != filter.Region.ValueString() {
+			continue
+		}
+
+		if !filter.SourceInstanceId.IsNull() && (snapshot.SourceInstanceId == nil || *snapshot.SourceInstanceId != filter.SourceInstanceId.ValueString()) {
+			continue
+		}
+
+		if !filter.SourceVolumeId.IsNull() && (snapshot.SourceVolumeId == nil || *snapshot.SourceVolumeId != filter.SourceVolumeId.ValueString()) {
+			continue
+		}
+
+		if createdBefore != nil && !snapshot.CreatedAt.Before(*createdBefore) {
+			continue
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	// Newest first, so that a retention count can be applied with slice()
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt)
+	})
+
+	return snapshots, nil
+}
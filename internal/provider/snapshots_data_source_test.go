@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccSnapshotsDataSourceConfig = `
+data "sagadata_snapshots" "test" {
+	filter = {
+		name_prefix = "terraform-provider-sagadata-"
+		older_than  = "1h"
+	}
+}
+`
+
+func TestAccSnapshotsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + testAccSnapshotsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.sagadata_snapshots.test", "snapshots.#"),
+					// Verify placeholder id attribute
+					resource.TestCheckResourceAttr("data.sagadata_snapshots.test", "id", "none"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterSnapshots(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	allSnapshots := []sagadata.Snapshot{
+		{
+			Id:               "old-backup",
+			Name:             "backup-old",
+			Region:           "NORD-NO-KRS-1",
+			SourceInstanceId: pointer("instance"),
+			CreatedAt:        now.Add(-48 * time.Hour),
+		},
+		{
+			Id:             "new-backup",
+			Name:           "backup-new",
+			Region:         "NORD-NO-KRS-1",
+			SourceVolumeId: pointer("volume"),
+			CreatedAt:      now.Add(-30 * time.Minute),
+		},
+		{
+			Id:               "middle-backup",
+			Name:             "backup-middle",
+			Region:           "EUW-NL-AMS-1",
+			SourceInstanceId: pointer("instance"),
+			CreatedAt:        now.Add(-2 * time.Hour),
+		},
+		{
+			Id:        "manual",
+			Name:      "manual",
+			Region:    "NORD-NO-KRS-1",
+			CreatedAt: now.Add(-time.Hour),
+		},
+	}
+
+	testCases := map[string]struct {
+		filter      SnapshotsFilterDataSourceModel
+		expectedIds []string
+		expectError bool
+	}{
+		"no filter": {
+			expectedIds: []string{"new-backup", "manual", "middle-backup", "old-backup"},
+		},
+		"name prefix": {
+			filter:      SnapshotsFilterDataSourceModel{NamePrefix: types.StringValue("backup-")},
+			expectedIds: []string{"new-backup", "middle-backup", "old-backup"},
+		},
+		"older than": {
+			filter:      SnapshotsFilterDataSourceModel{OlderThan: types.StringValue("90m")},
+			expectedIds: []string{"middle-backup", "old-backup"},
+		},
+		"region": {
+			filter:      SnapshotsFilterDataSourceModel{Region: types.StringValue("NORD-NO-KRS-1")},
+			expectedIds: []string{"new-backup", "manual", "old-backup"},
+		},
+		"source instance id": {
+			filter:      SnapshotsFilterDataSourceModel{SourceInstanceId: types.StringValue("instance")},
+			expectedIds: []string{"middle-backup", "old-backup"},
+		},
+		"source volume id": {
+			filter:      SnapshotsFilterDataSourceModel{SourceVolumeId: types.StringValue("volume")},
+			expectedIds: []string{"new-backup"},
+		},
+		"combined": {
+			filter: SnapshotsFilterDataSourceModel{
+				NamePrefix: types.StringValue("backup-"),
+				OlderThan:  types.StringValue("1h"),
+				Region:     types.StringValue("NORD-NO-KRS-1"),
+			},
+			expectedIds: []string{"old-backup"},
+		},
+		"no match": {
+			filter: SnapshotsFilterDataSourceModel{NamePrefix: types.StringValue("nightly-")},
+		},
+		"invalid older than": {
+			filter:      SnapshotsFilterDataSourceModel{OlderThan: types.StringValue("1 day")},
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			snapshots, err := filterSnapshots(allSnapshots, testCase.filter, now)
+
+			if (err != nil) != testCase.expectError {
+				t.Fatalf("expected error %t, got %v", testCase.expectError, err)
+			}
+
+			var ids []string
+			for _, snapshot := range snapshots {
+				ids = append(ids, snapshot.Id)
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(testCase.expectedIds) {
+				t.Errorf("expected ids %v, got %v", testCase.expectedIds, ids)
+			}
+		})
+	}
+}
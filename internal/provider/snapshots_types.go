@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/sagadata-public/sagadata-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SnapshotsFilterDataSourceModel struct {
+	// NamePrefix Filter by the beginning of the snapshot name.
+	NamePrefix types.String `tfsdk:"name_prefix"`
+
+	// OlderThan Filter by snapshots created longer ago than the given duration.
+	OlderThan types.String `tfsdk:"older_than"`
+
+	// Region Filter by the region identifier.
+	Region types.String `tfsdk:"region"`
+
+	// SourceInstanceId Filter by the id of the source instance.
+	SourceInstanceId types.String `tfsdk:"source_instance_id"`
+
+	// SourceVolumeId Filter by the id of the source volume.
+	SourceVolumeId types.String `tfsdk:"source_volume_id"`
+}
+
+// SnapshotsDataSourceModel describes the data source data model.
+type SnapshotsDataSourceModel struct {
+	Filter    *SnapshotsFilterDataSourceModel `tfsdk:"filter"`
+	Snapshots []SnapshotModel                 `tfsdk:"snapshots"`
+	Id        types.String                    `tfsdk:"id"` // placeholder
+
+	// Internal
+
+	// Timeouts The data source timeouts
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type SnapshotModel struct {
+	CreatedAt types.String `tfsdk:"created_at"`
+
+	// Id The unique ID of the snapshot.
+	Id types.String `tfsdk:"id"`
+
+	// Name The human-readable name for the snapshot.
+	Name types.String `tfsdk:"name"`
+
+	// Region The region identifier.
+	Region types.String `tfsdk:"region"`
+
+	// Size The storage size of this snapshot given in GiB.
+	Size types.Int64 `tfsdk:"size"`
+
+	// SourceInstanceId The id of the source instance from which this snapshot was derived.
+	SourceInstanceId types.String `tfsdk:"source_instance_id"`
+
+	// SourceSnapshotId The id of the source snapshot from which this snapshot was derived.
+	SourceSnapshotId types.String `tfsdk:"source_snapshot_id"`
+
+	// SourceVolumeId The id of the source volume from which this snapshot was derived.
+	SourceVolumeId types.String `tfsdk:"source_volume_id"`
+
+	// Status The snapshot status.
+	Status types.String `tfsdk:"status"`
+}
+
+func (data *SnapshotModel) PopulateFromClientResponse(ctx context.Context, snapshot *sagadata.Snapshot) (diag diag.Diagnostics) {
+	data.CreatedAt = types.StringValue(snapshot.CreatedAt.Format(time.RFC3339))
+	data.Id = types.StringValue(snapshot.Id)
+	data.Name = types.StringValue(snapshot.Name)
+	data.Region = types.StringValue(string(snapshot.Region))
+	data.Size = types.Int64Value(int64(snapshot.Size))
+	data.SourceInstanceId = types.StringPointerValue(snapshot.SourceInstanceId)
+	data.SourceSnapshotId = types.StringPointerValue(snapshot.SourceSnapshotId)
+	data.SourceVolumeId = types.StringPointerValue(snapshot.SourceVolumeId)
+	data.Status = types.StringValue(string(snapshot.Status))
+
+	return
+}
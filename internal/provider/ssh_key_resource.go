@@ -19,8 +19,16 @@ var (
 	_ resource.Resource                = &SSHKeyResource{}
 	_ resource.ResourceWithConfigure   = &SSHKeyResource{}
 	_ resource.ResourceWithImportState = &SSHKeyResource{}
+	_ resource.ResourceWithMoveState   = &SSHKeyResource{}
+	_ resource.ResourceWithIdentity    = &SSHKeyResource{}
 )
 
+// sshKeyErrorFieldAttributes maps the fields of the SSH key requests to their attributes.
+var sshKeyErrorFieldAttributes = map[string]string{
+	"name":  "name",
+	"value": "public_key",
+}
+
 func NewSSHKeyResource() resource.Resource {
 	return &SSHKeyResource{}
 }
@@ -29,6 +37,7 @@ func NewSSHKeyResource() resource.Resource {
 type SSHKeyResource struct {
 	ResourceWithClient
 	ResourceWithTimeout
+	ResourceWithIdIdentity
 }
 
 func (r *SSHKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -89,7 +98,7 @@ func (r *SSHKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_ssh_key").Create)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -109,7 +118,7 @@ func (r *SSHKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	sshkeyResponse := response.JSON201
 	if sshkeyResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("create ssh_key", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("create ssh_key", sshKeyErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -124,6 +133,8 @@ func (r *SSHKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	tflog.Trace(ctx, "created a ssh_key resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -137,7 +148,7 @@ func (r *SSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_ssh_key").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -146,7 +157,7 @@ func (r *SSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	sshKeyId := data.Id.ValueString()
 
-	response, err := r.client.GetSSHKeyWithResponse(ctx, sshKeyId)
+	response, err := r.client.GetSSHKeyCachedWithResponse(ctx, sshKeyId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read ssh_key", err))
 		return
@@ -154,7 +165,7 @@ func (r *SSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	sshkeyResponse := response.JSON200
 	if sshkeyResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read ssh_key", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read ssh_key", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -169,6 +180,8 @@ func (r *SSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	tflog.Trace(ctx, "read a ssh_key resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -182,7 +195,7 @@ func (r *SSHKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update, r.client.DefaultTimeouts("sagadata_ssh_key").Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -203,7 +216,7 @@ func (r *SSHKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	sshkeyResponse := response.JSON200
 	if sshkeyResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("update ssh_key", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("update ssh_key", sshKeyErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -231,7 +244,7 @@ func (r *SSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete, r.client.DefaultTimeouts("sagadata_ssh_key").Delete)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -248,7 +261,7 @@ func (r *SSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("delete ssh_key", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("delete ssh_key", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -258,5 +271,11 @@ func (r *SSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *SSHKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp, path.Root("id"), "ssh key", r.client, listSSHKeyImportCandidates)
+}
+
+func (r *SSHKeyResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		genesisCloudStateMover("_ssh_key", nil),
+	}
 }
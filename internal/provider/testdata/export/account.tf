@@ -0,0 +1,166 @@
+resource "sagadata_ssh_key" "alice_example_com" {
+  name       = "alice@example.com"
+  public_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBOpdKM8wSI07+PO4xLDL7zW/kNWGbdFXeHyBU1TRlBn alice@example.com"
+}
+
+import {
+  to = sagadata_ssh_key.alice_example_com
+  id = "8a1f6f1e-2b1d-4c6e-9f3a-1d2e3f4a5b01"
+}
+
+resource "sagadata_security_group" "allow_ssh" {
+  name        = "allow-ssh"
+  description = "Allow SSH"
+  region      = "NORD-NO-KRS-1"
+  rules = [{
+    direction      = "ingress"
+    port_range_max = 22
+    port_range_min = 22
+    protocol       = "tcp"
+    }, {
+    direction = "egress"
+    protocol  = "all"
+  }]
+}
+
+import {
+  to = sagadata_security_group.allow_ssh
+  id = "2c4e6a8b-0d1f-4a3c-8e5b-7f9a1b3c5d02"
+}
+
+resource "sagadata_floating_ip" "gateway" {
+  name    = "gateway"
+  region  = "NORD-NO-KRS-1"
+  version = "ipv4"
+}
+
+import {
+  to = sagadata_floating_ip.gateway
+  id = "5e7a9c1b-3d5f-4b7a-9c1e-3f5a7b9c1d03"
+}
+
+resource "sagadata_private_network" "cluster_network" {
+  name    = "cluster network"
+  region  = "NORD-NO-KRS-1"
+  cidr_v4 = "10.10.0.0/16"
+}
+
+import {
+  to = sagadata_private_network.cluster_network
+  id = "7b9d1f3a-5c7e-4d9b-8f1a-5c7e9d1f3a04"
+}
+
+resource "sagadata_volume" "data" {
+  name               = "data"
+  region             = "EUW-NL-AMS-1"
+  size               = 100
+  type               = "hdd"
+  source_snapshot_id = sagadata_snapshot.trainer_nightly.id
+}
+
+import {
+  to = sagadata_volume.data
+  id = "1f3b5d7e-9a1c-4b3f-9d5e-9a1c3b5d7e06"
+}
+
+resource "sagadata_volume" "data_2" {
+  name        = "data"
+  description = "Training data"
+  region      = "NORD-NO-KRS-1"
+  size        = 500
+  type        = "ssd"
+}
+
+import {
+  to = sagadata_volume.data_2
+  id = "9d1f3b5c-7e9a-4f1d-8b3c-7e9a1f3b5c05"
+}
+
+resource "sagadata_volume" "restored" {
+  name               = "restored"
+  description        = "Restored from the nightly snapshot of the trainer"
+  region             = "NORD-NO-KRS-1"
+  size               = 128
+  type               = "ssd"
+  source_snapshot_id = "6a8c0e2f-4b6d-4e8a-8c0e-2f4b6d8a0c10"
+}
+
+import {
+  to = sagadata_volume.restored
+  id = "b1d3f5a7-9c1e-4a3b-8d5f-7a9c1e3b5d15"
+}
+
+resource "sagadata_filesystem" "r_2025_checkpoints" {
+  name   = "2025 checkpoints"
+  region = "NORD-NO-KRS-1"
+  size   = 1000
+  type   = "vast"
+}
+
+import {
+  to = sagadata_filesystem.r_2025_checkpoints
+  id = "3b5d7f9a-1c3e-4d5b-8f7a-1c3e5d7f9a07"
+}
+
+resource "sagadata_kubernetes_cluster" "training" {
+  name    = "training"
+  network = sagadata_private_network.cluster_network.id
+}
+
+import {
+  to = sagadata_kubernetes_cluster.training
+  id = "4c6e8a0b-2d4f-4e6c-9a8b-2d4f6e8a0b08"
+}
+
+resource "sagadata_instance" "trainer" {
+  name                = "trainer"
+  hostname            = "trainer"
+  region              = "NORD-NO-KRS-1"
+  type                = "vcpu-4_memory-16g_nvidia-rtx-3080-1"
+  image               = "0e2a4c6d-8f0b-4a2c-9e4d-8f0b2a4c6e11"
+  disk_size           = 128
+  placement_option    = "AUTO"
+  ssh_key_ids         = [sagadata_ssh_key.alice_example_com.id]
+  security_group_ids  = [sagadata_security_group.allow_ssh.id, "e1a3c5e7-9b1d-4f3a-8c5e-7a9b1d3f5e12"]
+  volume_ids          = [sagadata_volume.data_2.id, sagadata_volume.restored.id]
+  private_network_ids = [sagadata_private_network.cluster_network.id]
+  floating_ip_id      = sagadata_floating_ip.gateway.id
+  k8s_cluster_id      = sagadata_kubernetes_cluster.training.id
+}
+
+import {
+  to = sagadata_instance.trainer
+  id = "5d7f9b1c-3e5a-4f7d-8b9c-3e5a7f9b1c09"
+}
+
+resource "sagadata_snapshot" "data_backup" {
+  name             = "data-backup"
+  source_volume_id = sagadata_volume.data_2.id
+}
+
+import {
+  to = sagadata_snapshot.data_backup
+  id = "a0c2e4b6-8d0f-4c2e-8a4b-6d8f0c2e4a14"
+}
+
+resource "sagadata_snapshot" "trainer_nightly" {
+  name               = "trainer-nightly"
+  source_instance_id = sagadata_instance.trainer.id
+}
+
+import {
+  to = sagadata_snapshot.trainer_nightly
+  id = "6a8c0e2f-4b6d-4e8a-8c0e-2f4b6d8a0c10"
+}
+
+resource "sagadata_snapshot" "trainer_nightly_2" {
+  name               = "trainer-nightly"
+  region             = "EUW-NL-AMS-1"
+  source_snapshot_id = sagadata_snapshot.trainer_nightly.id
+}
+
+import {
+  to = sagadata_snapshot.trainer_nightly_2
+  id = "8c0e2a4b-6d8f-4a0c-9e2a-4b6d8f0a2c13"
+}
+
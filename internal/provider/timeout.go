@@ -11,7 +11,59 @@ const defaultTimeout = 20 * time.Minute
 
 type CreateFn = func(ctx context.Context, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics)
 
-func contextWithTimeout(ctx context.Context, timeoutFn CreateFn) (context.Context, context.CancelFunc, diag.Diagnostics) {
+// Timeouts are the timeouts of the operations of a resource. A zero timeout is
+// not set.
+type Timeouts struct {
+	Create time.Duration
+	Read   time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// orDefault returns the timeouts with unset timeouts taken from the defaults.
+func (t Timeouts) orDefault(defaults Timeouts) Timeouts {
+	if t.Create == 0 {
+		t.Create = defaults.Create
+	}
+	if t.Read == 0 {
+		t.Read = defaults.Read
+	}
+	if t.Update == 0 {
+		t.Update = defaults.Update
+	}
+	if t.Delete == 0 {
+		t.Delete = defaults.Delete
+	}
+	return t
+}
+
+// ClientTimeouts are the timeouts configured on the provider.
+type ClientTimeouts struct {
+	// Default The timeouts of all resources and data sources.
+	Default Timeouts
+
+	// Resources The timeouts by resource or data source type name, e.g. "sagadata_instance".
+	Resources map[string]Timeouts
+}
+
+// DefaultTimeouts returns the timeouts of a resource or data source type which
+// are used when the timeouts of the resource or data source are not set.
+func (c *Client) DefaultTimeouts(typeName string) Timeouts {
+	builtin := Timeouts{
+		Create: defaultTimeout,
+		Read:   defaultTimeout,
+		Update: defaultTimeout,
+		Delete: defaultTimeout,
+	}
+
+	if c == nil {
+		return builtin
+	}
+
+	return c.Timeouts.Resources[typeName].orDefault(c.Timeouts.Default).orDefault(builtin)
+}
+
+func contextWithTimeout(ctx context.Context, timeoutFn CreateFn, defaultTimeout time.Duration) (context.Context, context.CancelFunc, diag.Diagnostics) {
 	timeout, diag := timeoutFn(ctx, defaultTimeout)
 	if diag != nil {
 		return ctx, nil, diag
@@ -28,10 +80,14 @@ type DataSourceWithTimeout struct {
 type ResourceWithTimeout struct {
 }
 
-func (d *DataSourceWithTimeout) ContextWithTimeout(ctx context.Context, timeoutFn CreateFn) (context.Context, context.CancelFunc, diag.Diagnostics) {
-	return contextWithTimeout(ctx, timeoutFn)
+// ContextWithTimeout returns a context with the timeout of the data source or
+// the given default timeout if it is not set.
+func (d *DataSourceWithTimeout) ContextWithTimeout(ctx context.Context, timeoutFn CreateFn, defaultTimeout time.Duration) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	return contextWithTimeout(ctx, timeoutFn, defaultTimeout)
 }
 
-func (r *ResourceWithTimeout) ContextWithTimeout(ctx context.Context, timeoutFn CreateFn) (context.Context, context.CancelFunc, diag.Diagnostics) {
-	return contextWithTimeout(ctx, timeoutFn)
+// ContextWithTimeout returns a context with the timeout of the resource or the
+// given default timeout if it is not set.
+func (r *ResourceWithTimeout) ContextWithTimeout(ctx context.Context, timeoutFn CreateFn, defaultTimeout time.Duration) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	return contextWithTimeout(ctx, timeoutFn, defaultTimeout)
 }
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClientDefaultTimeouts(t *testing.T) {
+	client := &Client{
+		Timeouts: ClientTimeouts{
+			Default: Timeouts{
+				Read: 2 * time.Minute,
+			},
+			Resources: map[string]Timeouts{
+				"sagadata_instance": {
+					Create: time.Hour,
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		client   *Client
+		typeName string
+		expected Timeouts
+	}{
+		"unconfigured client": {
+			typeName: "sagadata_instance",
+			expected: Timeouts{Create: defaultTimeout, Read: defaultTimeout, Update: defaultTimeout, Delete: defaultTimeout},
+		},
+		"default timeouts": {
+			client:   client,
+			typeName: "sagadata_volume",
+			expected: Timeouts{Create: defaultTimeout, Read: 2 * time.Minute, Update: defaultTimeout, Delete: defaultTimeout},
+		},
+		"resource timeouts": {
+			client:   client,
+			typeName: "sagadata_instance",
+			expected: Timeouts{Create: time.Hour, Read: 2 * time.Minute, Update: defaultTimeout, Delete: defaultTimeout},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if actual := testCase.client.DefaultTimeouts(testCase.typeName); actual != testCase.expected {
+				t.Errorf("expected timeouts %+v, got %+v", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 
 	"github.com/sagadata-public/sagadata-go"
+	"github.com/sagadata-public/terraform-provider-sagadata/internal/decreaseplanmodifier"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/defaultplanmodifier"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,9 +25,22 @@ import (
 var (
 	_ resource.Resource                = &VolumeResource{}
 	_ resource.ResourceWithConfigure   = &VolumeResource{}
+	_ resource.ResourceWithModifyPlan  = &VolumeResource{}
 	_ resource.ResourceWithImportState = &VolumeResource{}
+	_ resource.ResourceWithMoveState   = &VolumeResource{}
+	_ resource.ResourceWithIdentity    = &VolumeResource{}
 )
 
+// volumeErrorFieldAttributes maps the fields of the volume requests to their attributes.
+var volumeErrorFieldAttributes = map[string]string{
+	"description":        "description",
+	"name":               "name",
+	"region":             "region",
+	"size":               "size",
+	"source_snapshot_id": "source_snapshot_id",
+	"type":               "type",
+}
+
 func NewVolumeResource() resource.Resource {
 	return &VolumeResource{}
 }
@@ -34,6 +49,7 @@ func NewVolumeResource() resource.Resource {
 type VolumeResource struct {
 	ResourceWithClient
 	ResourceWithTimeout
+	ResourceWithIdIdentity
 }
 
 func (r *VolumeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -73,9 +89,11 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Required:            true,
 			}),
 			"region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "The region identifier.",
-				Required:            true,
+				MarkdownDescription: "The region identifier. Defaults to the `default_region` of the provider.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -85,10 +103,20 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"size": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
 				MarkdownDescription: "The storage size of this volume given in GiB.",
 				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					decreaseplanmodifier.Int64(path.Root("on_size_decrease")),
+				},
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
 				},
 			}),
+			"source_snapshot_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The id of the source snapshot from which this volume is restored. If omitted, an empty volume is created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			}),
 			"status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The volume status.",
 				Computed:            true,
@@ -108,6 +136,18 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			}),
 
 			// Internal
+			"on_size_decrease": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "What to do when `size` is decreased, as the volume cannot be shrunk in place. " +
+					"`error` rejects the plan, `replace` destroys and recreates the volume, losing its data.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					defaultplanmodifier.String(decreaseplanmodifier.BehaviorError),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(decreaseplanmodifier.AllBehaviors...),
+				},
+			}),
 			"retain_on_delete": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
 				MarkdownDescription: "Flag to retain the volume when the resource is deleted",
 				Optional:            true,
@@ -117,11 +157,18 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			}),
 
+			"deletion_protection": deletionProtectionAttribute(ctx, "volume"),
+
 			"timeouts": timeouts.AttributesAll(ctx),
 		},
 	}
 }
 
+func (r *VolumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanDefaultRegion(ctx, r.client, req, resp)
+	modifyPlanDeletionProtection(ctx, "volume", req, resp)
+}
+
 func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data VolumeResourceModel
 
@@ -131,7 +178,7 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create, r.client.DefaultTimeouts("sagadata_volume").Create)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -146,6 +193,10 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 	body.Size = int(data.Size.ValueInt64())
 	body.Type = pointer(sagadata.VolumeType(data.Type.ValueString()))
 
+	if !data.SourceSnapshotId.IsNull() && !data.SourceSnapshotId.IsUnknown() {
+		body.SourceSnapshotId = data.SourceSnapshotId.ValueStringPointer()
+	}
+
 	response, err := r.client.CreateVolumeWithResponse(ctx, body)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("create volume", err))
@@ -154,7 +205,7 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	volumeResponse := response.JSON201
 	if volumeResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("create volume", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("create volume", volumeErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -169,6 +220,8 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	tflog.Trace(ctx, "created a volume resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -177,48 +230,69 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	volumeId := volumeResponse.Volume.Id
 
+	volume, diags := r.waitForCreated(ctx, volumeId)
+	if diags.HasError() && isWaitTimeout(ctx) {
+		resp.Diagnostics.Append(markProvisioning(ctx, resp.Private, "volume")...)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, volume)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if volume.Status == sagadata.VolumeStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling volume", ErrResourceInErrorState))
+	}
+}
+
+// volumeProvisioned returns whether the volume finished provisioning.
+func volumeProvisioned(volume *sagadata.Volume) bool {
+	return volume.Status == sagadata.VolumeStatusCreated || volume.Status == sagadata.VolumeStatusError
+}
+
+// waitForCreated polls the volume until it finished provisioning.
+func (r *VolumeResource) waitForCreated(ctx context.Context, volumeId string) (*sagadata.Volume, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	for {
 		err := r.client.PollingWait(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling volume", err))
-			return
+			diags.AddError("Polling Error", generateErrorMessage("polling volume", err))
+			return nil, diags
 		}
 
 		tflog.Trace(ctx, "polling a volume resource")
 
 		response, err := r.client.GetVolumeWithResponse(ctx, volumeId)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling volume", err))
-			return
+			diags.AddError("Client Error", generateErrorMessage("polling volume", err))
+			return nil, diags
 		}
 
 		volumeResponse := response.JSON200
 		if volumeResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling volume", ErrorResponse{
+			diags.Append(newClientErrorDiagnostic("polling volume", nil, ErrorResponse{
 				Body:         response.Body,
 				HTTPResponse: response.HTTPResponse,
 				Error:        response.JSONDefault,
 			}))
-			return
+			return nil, diags
 		}
 
-		status := volumeResponse.Volume.Status
-		if status == sagadata.VolumeStatusCreated || status == sagadata.VolumeStatusError {
-			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &volumeResponse.Volume)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			if status == sagadata.VolumeStatusError {
-				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling volume", ErrResourceInErrorState))
-			}
-			return
+		if volumeProvisioned(&volumeResponse.Volume) {
+			return &volumeResponse.Volume, diags
 		}
 	}
 }
@@ -232,7 +306,7 @@ func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read, r.client.DefaultTimeouts("sagadata_volume").Read)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -241,7 +315,7 @@ func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	volumeId := data.Id.ValueString()
 
-	response, err := r.client.GetVolumeWithResponse(ctx, volumeId)
+	response, err := r.client.GetVolumeCachedWithResponse(ctx, volumeId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read volume", err))
 		return
@@ -249,7 +323,7 @@ func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	volumeResponse := response.JSON200
 	if volumeResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read volume", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("read volume", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -257,19 +331,27 @@ func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &volumeResponse.Volume)...)
+	volume, diags := resumeProvisioning(ctx, resp.Private, "volume", volumeId, &volumeResponse.Volume, volumeProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, volume)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Trace(ctx, "read a volume resource")
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, path.Root("id"), data.Id)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data VolumeResourceModel
+	var data, state VolumeResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -277,13 +359,25 @@ func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update, r.client.DefaultTimeouts("sagadata_volume").Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 	defer cancel()
 
+	_, diags := resumeProvisioning(ctx, resp.Private, "volume", data.Id.ValueString(), nil, volumeProvisioned, r.waitForCreated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	body := sagadata.UpdateVolumeJSONRequestBody{}
 
 	body.Name = pointer(data.Name.ValueString())
@@ -291,6 +385,7 @@ func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 	body.Size = pointer(int(data.Size.ValueInt64()))
 
 	volumeId := data.Id.ValueString()
+	targetSize := *body.Size
 
 	response, err := r.client.UpdateVolumeWithResponse(ctx, volumeId, body)
 	if err != nil {
@@ -300,7 +395,7 @@ func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	volumeResponse := response.JSON200
 	if volumeResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("update volume", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("update volume", volumeErrorFieldAttributes, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -317,6 +412,62 @@ func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if int64(targetSize) == state.Size.ValueInt64() {
+		return
+	}
+
+	tflog.Info(ctx, "resizing volume", map[string]interface{}{"id": volumeId, "from": state.Size.ValueInt64(), "to": targetSize})
+
+	for {
+		err := r.client.PollingWait(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling volume resize", err))
+			return
+		}
+
+		response, err := r.client.GetVolumeWithResponse(ctx, volumeId)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling volume resize", err))
+			return
+		}
+
+		volumeResponse := response.JSON200
+		if volumeResponse == nil {
+			resp.Diagnostics.Append(newClientErrorDiagnostic("polling volume resize", nil, ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return
+		}
+
+		status := volumeResponse.Volume.Status
+		size := volumeResponse.Volume.Size
+
+		tflog.Info(ctx, "polling volume resize", map[string]interface{}{"id": volumeId, "status": status, "size": size, "target_size": targetSize})
+
+		if (status == sagadata.VolumeStatusCreated && size == targetSize) || status == sagadata.VolumeStatusError {
+			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &volumeResponse.Volume)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Save updated data into Terraform state
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			if status == sagadata.VolumeStatusError {
+				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling volume resize", ErrResourceInErrorState))
+			}
+			return
+		}
+	}
 }
 
 func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -328,7 +479,7 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete, r.client.DefaultTimeouts("sagadata_volume").Delete)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -337,6 +488,11 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	volumeId := data.Id.ValueString()
 
+	resp.Diagnostics.Append(checkDeletionProtection("volume", volumeId, data.DeletionProtection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if data.RetainOnDelete.ValueBool() {
 		resp.Diagnostics.AddWarning(
 			"Volume is retained",
@@ -352,7 +508,7 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("delete volume", ErrorResponse{
+		resp.Diagnostics.Append(newClientErrorDiagnostic("delete volume", nil, ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -382,5 +538,11 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *VolumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp, path.Root("id"), "volume", r.client, listVolumeImportCandidates)
+}
+
+func (r *VolumeResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		genesisCloudStateMover("_volume", nil),
+	}
 }
@@ -43,6 +43,13 @@ func TestAccVolumeResource(t *testing.T) {
 					resource.TestCheckResourceAttr("sagadata_volume.test", "name", "two"),
 				),
 			},
+			// Resize testing
+			{
+				Config: providerConfig + testAccVolumeResourceConfig("two", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sagadata_volume.test", "size", "2"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
@@ -28,6 +28,9 @@ type VolumeResourceModel struct {
 	// Size The storage size of this volume given in GiB.
 	Size types.Int64 `tfsdk:"size"`
 
+	// SourceSnapshotId The id of the source snapshot from which this volume was restored.
+	SourceSnapshotId types.String `tfsdk:"source_snapshot_id"`
+
 	// Status The volume status.
 	Status types.String `tfsdk:"status"`
 
@@ -36,9 +39,15 @@ type VolumeResourceModel struct {
 
 	// Internal
 
+	// OnSizeDecrease What to do when the size of the volume is decreased.
+	OnSizeDecrease types.String `tfsdk:"on_size_decrease"`
+
 	// RetainOnDelete Flag to retain the volume when the resource is deleted. It has to be deleted manually.
 	RetainOnDelete types.Bool `tfsdk:"retain_on_delete"`
 
+	// DeletionProtection Flag to prevent the volume from being deleted.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
 	// Timeouts The resource timeouts
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
@@ -53,5 +62,9 @@ func (data *VolumeResourceModel) PopulateFromClientResponse(ctx context.Context,
 	data.Status = types.StringValue(string(volume.Status))
 	data.Type = types.StringValue(string(volume.Type))
 
+	if volume.SourceSnapshotId != nil {
+		data.SourceSnapshotId = types.StringValue(*volume.SourceSnapshotId)
+	}
+
 	return
 }
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultWaitForTimeout = 10 * time.Minute
+	waitForDialTimeout    = 5 * time.Second
+)
+
+// waitForTCPPort polls the port until it accepts connections or the context
+// is done.
+func waitForTCPPort(ctx context.Context, host string, port int, interval time.Duration) error {
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	dialer := net.Dialer{Timeout: waitForDialTimeout}
+
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err == nil {
+			return conn.Close()
+		}
+
+		tflog.Debug(ctx, "waiting for the tcp port", map[string]any{"address": address, "error": err.Error()})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s did not accept connections: %w", address, err)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// waitForInstance waits until the instance is usable as configured by the
+// wait_for attribute, with its own timeout.
+func (r *InstanceResource) waitForInstance(ctx context.Context, data *InstanceResourceModel) (diags diag.Diagnostics) {
+	if data.WaitFor == nil {
+		return
+	}
+
+	timeout := defaultWaitForTimeout
+	if !data.WaitFor.Timeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(data.WaitFor.Timeout.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("wait_for").AtName("timeout"), "Invalid Timeout", err.Error())
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	address := "public_ip"
+	if !data.WaitFor.Address.IsNull() {
+		address = data.WaitFor.Address.ValueString()
+	}
+
+	host := data.PublicIp
+	if address == "private_ip" {
+		host = data.PrivateIp
+	}
+
+	if host.ValueString() == "" {
+		diags.AddAttributeError(
+			path.Root("wait_for").AtName("address"),
+			"Instance Not Ready",
+			fmt.Sprintf("The instance has no %s to wait for.", address),
+		)
+		return
+	}
+
+	port := int(data.WaitFor.TcpPort.ValueInt64())
+
+	tflog.Info(ctx, "waiting for the instance to accept connections", map[string]any{"host": host.ValueString(), "port": port})
+
+	err := waitForTCPPort(ctx, host.ValueString(), port, r.client.PollingInterval)
+	if err != nil {
+		diags.AddError(
+			"Instance Not Ready",
+			fmt.Sprintf("The instance did not become ready within %s: %s", timeout, err),
+		)
+	}
+
+	return
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listen returns a local TCP listener which accepts and closes connections.
+func listen(t *testing.T) *net.TCPAddr {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr)
+}
+
+// closedPort returns a local TCP port which refuses connections.
+func closedPort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	return port
+}
+
+func TestWaitForTCPPort(t *testing.T) {
+	addr := listen(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := waitForTCPPort(ctx, "127.0.0.1", addr.Port, 10*time.Millisecond); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWaitForTCPPortTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := waitForTCPPort(ctx, "127.0.0.1", closedPort(t), 10*time.Millisecond); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestWaitForTCPPortDelayed(t *testing.T) {
+	port := closedPort(t)
+
+	listening := make(chan net.Listener, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+
+		listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+		if err != nil {
+			close(listening)
+			return
+		}
+		listening <- listener
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	err := waitForTCPPort(ctx, "127.0.0.1", port, 10*time.Millisecond)
+
+	listener, ok := <-listening
+	if !ok {
+		t.Skip("the port was taken in the meantime")
+	}
+	listener.Close()
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestInstanceWaitForInstance(t *testing.T) {
+	ctx := context.Background()
+
+	r := &InstanceResource{ResourceWithClient: ResourceWithClient{client: &Client{PollingInterval: 10 * time.Millisecond}}}
+
+	addr := listen(t)
+
+	testCases := []struct {
+		name    string
+		waitFor *InstanceWaitForModel
+		error   bool
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "public ip",
+			waitFor: &InstanceWaitForModel{
+				TcpPort: types.Int64Value(int64(addr.Port)),
+			},
+		},
+		{
+			name: "private ip",
+			waitFor: &InstanceWaitForModel{
+				TcpPort: types.Int64Value(int64(addr.Port)),
+				Address: types.StringValue("private_ip"),
+			},
+			error: true,
+		},
+		{
+			name: "timeout",
+			waitFor: &InstanceWaitForModel{
+				TcpPort: types.Int64Value(int64(closedPort(t))),
+				Timeout: types.StringValue("100ms"),
+			},
+			error: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := &InstanceResourceModel{
+				PublicIp:  types.StringValue("127.0.0.1"),
+				PrivateIp: types.StringNull(),
+				WaitFor:   tc.waitFor,
+			}
+
+			diags := r.waitForInstance(ctx, data)
+			if diags.HasError() != tc.error {
+				t.Errorf("expected error %t, got %v", tc.error, diags)
+			}
+		})
+	}
+}
@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/sagadata-public/sagadata-go"
 	"github.com/sagadata-public/terraform-provider-sagadata/internal/provider"
 )
 
@@ -29,6 +32,11 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		export(os.Args[2:])
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -45,3 +53,61 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// export writes all resources of the account as HCL with import blocks.
+func export(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+
+	endpoint := flags.String("endpoint", os.Getenv("SAGADATA_ENDPOINT"), "Saga Data API endpoint, defaults to the SAGADATA_ENDPOINT env var")
+	token := flags.String("token", os.Getenv("SAGADATA_TOKEN"), "Saga Data API token, defaults to the SAGADATA_TOKEN env var")
+	out := flags.String("out", "", "file to write the HCL to, defaults to stdout")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [flags]\n\nWrites the resources of the account as HCL with import blocks.\n\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	if *endpoint == "" {
+		*endpoint = sagadata.DefaultEndpoint
+	}
+
+	if *token == "" {
+		log.Fatal("Missing Saga Data API token, set the SAGADATA_TOKEN env var or use -token")
+	}
+
+	ctx := context.Background()
+
+	client, err := provider.NewClient(ctx, provider.ClientConfig{
+		ClientConfig: sagadata.ClientConfig{
+			Endpoint: *endpoint,
+			Token:    *token,
+		},
+	})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	account, diags := provider.FetchExportAccount(ctx, client)
+	for _, d := range diags {
+		log.Printf("%s: %s", d.Summary(), d.Detail())
+	}
+	if diags.HasError() {
+		os.Exit(1)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		w, err = os.Create(*out)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		defer w.Close()
+	}
+
+	err = provider.WriteExport(w, account)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}